@@ -0,0 +1,165 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// AffixAttributesConfig configures the prefix_attributes and suffix_attributes sub-processors.
+type AffixAttributesConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Patterns are attribute keys to change. The `*` character matches any sequence of characters.
+	Patterns []string `mapstructure:"patterns"`
+	// WildcardOptions change how Patterns are matched.
+	WildcardOptions `mapstructure:",squash"`
+	// Affix is the prefix or suffix added to the keys.
+	Affix string `mapstructure:"affix"`
+	// Overwrite defines whether an attribute which already has the new key should be overwritten.
+	Overwrite bool `mapstructure:"overwrite"`
+}
+
+// affixAttributesProcessor adds a prefix or a suffix to attribute keys.
+type affixAttributesProcessor struct {
+	enabled      bool
+	prefix       bool
+	affix        string
+	overwrite    bool
+	regexes      []*regexp.Regexp
+	maxKeyLength int
+	propertyName string
+}
+
+func newPrefixAttributesProcessor(config *AffixAttributesConfig) (*affixAttributesProcessor, error) {
+	return newAffixAttributesProcessor(config, true, "prefix_attributes")
+}
+
+func newSuffixAttributesProcessor(config *AffixAttributesConfig) (*affixAttributesProcessor, error) {
+	return newAffixAttributesProcessor(config, false, "suffix_attributes")
+}
+
+func newAffixAttributesProcessor(config *AffixAttributesConfig, prefix bool, propertyName string) (*affixAttributesProcessor, error) {
+	if config.Enabled {
+		if err := validateAffixAttributesConfig(config); err != nil {
+			return nil, err
+		}
+	}
+
+	regexes, err := compileWildcards(config.Patterns, config.WildcardOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &affixAttributesProcessor{
+		enabled:      config.Enabled,
+		prefix:       prefix,
+		affix:        config.Affix,
+		overwrite:    config.Overwrite,
+		regexes:      regexes,
+		propertyName: propertyName,
+	}, nil
+}
+
+func validateAffixAttributesConfig(config *AffixAttributesConfig) error {
+	if config.Affix == "" {
+		return errors.New("affix must not be empty")
+	}
+	return nil
+}
+
+func (proc *affixAttributesProcessor) ProcessLogs(logs plog.Logs) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnLogs(proc, logs)
+	}
+	return nil
+}
+
+func (proc *affixAttributesProcessor) ProcessMetrics(metrics pmetric.Metrics) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnMetrics(proc, metrics)
+	}
+	return nil
+}
+
+func (proc *affixAttributesProcessor) ProcessTraces(traces ptrace.Traces) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnTraces(proc, traces)
+	}
+	return nil
+}
+
+func (proc *affixAttributesProcessor) IsEnabled() bool {
+	return proc.enabled
+}
+
+func (proc *affixAttributesProcessor) ConfigPropertyName() string {
+	return proc.propertyName
+}
+
+func (proc *affixAttributesProcessor) setMaxKeyLength(maxKeyLength int) {
+	proc.maxKeyLength = maxKeyLength
+}
+
+func (proc *affixAttributesProcessor) processAttributes(attributes pcommon.Map) int {
+	// Keys are collected first, because the map must not be modified while iterating over it.
+	// They are sorted, so that the result of collisions is deterministic.
+	keys := []string{}
+	attributes.Range(func(key string, _ pcommon.Value) bool {
+		if !proc.hasAffix(key) && matchesAnyKeyRegex(proc.regexes, key, proc.maxKeyLength) {
+			keys = append(keys, key)
+		}
+		return true
+	})
+	sort.Strings(keys)
+
+	removed := 0
+	for _, key := range keys {
+		newKey := proc.addAffix(key)
+		_, exists := attributes.Get(newKey)
+		if exists && !proc.overwrite {
+			continue
+		}
+		if exists {
+			removed++
+		}
+
+		value, _ := attributes.Get(key)
+		attributes.Upsert(newKey, value)
+		attributes.Remove(key)
+	}
+	return removed
+}
+
+func (proc *affixAttributesProcessor) hasAffix(key string) bool {
+	if proc.prefix {
+		return strings.HasPrefix(key, proc.affix)
+	}
+	return strings.HasSuffix(key, proc.affix)
+}
+
+func (proc *affixAttributesProcessor) addAffix(key string) string {
+	if proc.prefix {
+		return proc.affix + key
+	}
+	return key + proc.affix
+}
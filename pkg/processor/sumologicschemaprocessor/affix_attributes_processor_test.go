@@ -0,0 +1,168 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestPrefixAttributes(t *testing.T) {
+	testCases := []struct {
+		name      string
+		overwrite bool
+		input     map[string]interface{}
+		expected  map[string]interface{}
+	}{
+		{
+			name: "adds prefix to matching keys",
+			input: map[string]interface{}{
+				"version": "1.0",
+				"build":   "123",
+				"other":   "a",
+			},
+			expected: map[string]interface{}{
+				"app.version": "1.0",
+				"app.build":   "123",
+				"other":       "a",
+			},
+		},
+		{
+			name: "skips keys which already have the prefix",
+			input: map[string]interface{}{
+				"app.commit": "abc",
+			},
+			expected: map[string]interface{}{
+				"app.commit": "abc",
+			},
+		},
+		{
+			name: "does not overwrite on collision",
+			input: map[string]interface{}{
+				"version":     "1.0",
+				"app.version": "2.0",
+			},
+			expected: map[string]interface{}{
+				"version":     "1.0",
+				"app.version": "2.0",
+			},
+		},
+		{
+			name:      "overwrites on collision",
+			overwrite: true,
+			input: map[string]interface{}{
+				"version":     "1.0",
+				"app.version": "2.0",
+			},
+			expected: map[string]interface{}{
+				"app.version": "1.0",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			processor, err := newPrefixAttributesProcessor(&AffixAttributesConfig{
+				Enabled:   true,
+				Patterns:  []string{"version", "build", "*commit"},
+				Affix:     "app.",
+				Overwrite: testCase.overwrite,
+			})
+			require.NoError(t, err)
+
+			attributes := pcommon.NewMapFromRaw(testCase.input)
+			processor.processAttributes(attributes)
+
+			assert.Equal(t, testCase.expected, attributes.AsRaw())
+		})
+	}
+}
+
+func TestSuffixAttributes(t *testing.T) {
+	processor, err := newSuffixAttributesProcessor(&AffixAttributesConfig{
+		Enabled:  true,
+		Patterns: []string{"duration*"},
+		Affix:    "_ms",
+	})
+	require.NoError(t, err)
+
+	attributes := pcommon.NewMapFromRaw(map[string]interface{}{
+		"duration":        int64(10),
+		"duration.total":  int64(20),
+		"duration.db_ms":  int64(30),
+		"duration.net":    int64(40),
+		"duration.net_ms": int64(50),
+	})
+	processor.processAttributes(attributes)
+
+	assert.Equal(t, map[string]interface{}{
+		"duration_ms":       int64(10),
+		"duration.total_ms": int64(20),
+		"duration.db_ms":    int64(30),
+		"duration.net":      int64(40),
+		"duration.net_ms":   int64(50),
+	}, attributes.AsRaw())
+}
+
+func TestAffixAttributesAllSignals(t *testing.T) {
+	processor, err := newPrefixAttributesProcessor(&AffixAttributesConfig{
+		Enabled:  true,
+		Patterns: []string{"version"},
+		Affix:    "app.",
+	})
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	resourceLogs.Resource().Attributes().InsertString("version", "a")
+	logRecord := resourceLogs.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	logRecord.Attributes().InsertString("version", "b")
+	require.NoError(t, processor.ProcessLogs(logs))
+	assertAttribute(t, resourceLogs.Resource().Attributes(), "app.version", "a")
+	assertAttribute(t, logRecord.Attributes(), "app.version", "b")
+
+	metrics := pmetric.NewMetrics()
+	resourceMetrics := metrics.ResourceMetrics().AppendEmpty()
+	resourceMetrics.Resource().Attributes().InsertString("version", "a")
+	metric := resourceMetrics.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetDataType(pmetric.MetricDataTypeGauge)
+	dataPoint := metric.Gauge().DataPoints().AppendEmpty()
+	dataPoint.Attributes().InsertString("version", "b")
+	require.NoError(t, processor.ProcessMetrics(metrics))
+	assertAttribute(t, resourceMetrics.Resource().Attributes(), "app.version", "a")
+	assertAttribute(t, dataPoint.Attributes(), "app.version", "b")
+
+	traces := ptrace.NewTraces()
+	resourceSpans := traces.ResourceSpans().AppendEmpty()
+	resourceSpans.Resource().Attributes().InsertString("version", "a")
+	span := resourceSpans.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.Attributes().InsertString("version", "b")
+	require.NoError(t, processor.ProcessTraces(traces))
+	assertAttribute(t, resourceSpans.Resource().Attributes(), "app.version", "a")
+	assertAttribute(t, span.Attributes(), "app.version", "b")
+}
+
+func TestAffixAttributesEmptyAffix(t *testing.T) {
+	_, err := newSuffixAttributesProcessor(&AffixAttributesConfig{
+		Enabled: true,
+	})
+	assert.EqualError(t, err, "affix must not be empty")
+}
@@ -0,0 +1,298 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// processLogsAttributes calls processAttributes on resource attributes and log record attributes.
+func processLogsAttributes(logs plog.Logs, processAttributes func(pcommon.Map)) {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		resourceLogs := logs.ResourceLogs().At(i)
+		processAttributes(resourceLogs.Resource().Attributes())
+
+		for j := 0; j < resourceLogs.ScopeLogs().Len(); j++ {
+			logRecords := resourceLogs.ScopeLogs().At(j).LogRecords()
+
+			for k := 0; k < logRecords.Len(); k++ {
+				processAttributes(logRecords.At(k).Attributes())
+			}
+		}
+	}
+}
+
+// processMetricsAttributes calls processAttributes on resource attributes and data point attributes.
+func processMetricsAttributes(metrics pmetric.Metrics, processAttributes func(pcommon.Map)) {
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		resourceMetrics := metrics.ResourceMetrics().At(i)
+		processAttributes(resourceMetrics.Resource().Attributes())
+
+		for j := 0; j < resourceMetrics.ScopeMetrics().Len(); j++ {
+			metricsSlice := resourceMetrics.ScopeMetrics().At(j).Metrics()
+
+			for k := 0; k < metricsSlice.Len(); k++ {
+				processDataPointsAttributes(metricsSlice.At(k), processAttributes)
+			}
+		}
+	}
+}
+
+// processTracesAttributes calls processAttributes on resource attributes and span attributes.
+func processTracesAttributes(traces ptrace.Traces, processAttributes func(pcommon.Map)) {
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		resourceSpans := traces.ResourceSpans().At(i)
+		processAttributes(resourceSpans.Resource().Attributes())
+
+		for j := 0; j < resourceSpans.ScopeSpans().Len(); j++ {
+			spans := resourceSpans.ScopeSpans().At(j).Spans()
+
+			for k := 0; k < spans.Len(); k++ {
+				processAttributes(spans.At(k).Attributes())
+			}
+		}
+	}
+}
+
+// processLogRecords calls processLogRecord on every log record.
+func processLogRecords(logs plog.Logs, processLogRecord func(plog.LogRecord)) {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		resourceLogs := logs.ResourceLogs().At(i)
+
+		for j := 0; j < resourceLogs.ScopeLogs().Len(); j++ {
+			logRecords := resourceLogs.ScopeLogs().At(j).LogRecords()
+
+			for k := 0; k < logRecords.Len(); k++ {
+				processLogRecord(logRecords.At(k))
+			}
+		}
+	}
+}
+
+// processLogRecordsAttributes calls processAttributes on log record attributes
+// together with attributes of the resource the log record belongs to.
+func processLogRecordsAttributes(logs plog.Logs, processAttributes func(resourceAttributes pcommon.Map, attributes pcommon.Map)) {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		resourceLogs := logs.ResourceLogs().At(i)
+		resourceAttributes := resourceLogs.Resource().Attributes()
+
+		for j := 0; j < resourceLogs.ScopeLogs().Len(); j++ {
+			logRecords := resourceLogs.ScopeLogs().At(j).LogRecords()
+
+			for k := 0; k < logRecords.Len(); k++ {
+				processAttributes(resourceAttributes, logRecords.At(k).Attributes())
+			}
+		}
+	}
+}
+
+// processDataPointsAttributesWithResource calls processAttributes on data point attributes
+// together with attributes of the resource the data point belongs to.
+func processDataPointsAttributesWithResource(metrics pmetric.Metrics, processAttributes func(resourceAttributes pcommon.Map, attributes pcommon.Map)) {
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		resourceMetrics := metrics.ResourceMetrics().At(i)
+		resourceAttributes := resourceMetrics.Resource().Attributes()
+
+		for j := 0; j < resourceMetrics.ScopeMetrics().Len(); j++ {
+			metricsSlice := resourceMetrics.ScopeMetrics().At(j).Metrics()
+
+			for k := 0; k < metricsSlice.Len(); k++ {
+				processDataPointsAttributes(metricsSlice.At(k), func(attributes pcommon.Map) {
+					processAttributes(resourceAttributes, attributes)
+				})
+			}
+		}
+	}
+}
+
+// processSpansAttributes calls processAttributes on span attributes
+// together with attributes of the resource the span belongs to.
+func processSpansAttributes(traces ptrace.Traces, processAttributes func(resourceAttributes pcommon.Map, attributes pcommon.Map)) {
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		resourceSpans := traces.ResourceSpans().At(i)
+		resourceAttributes := resourceSpans.Resource().Attributes()
+
+		for j := 0; j < resourceSpans.ScopeSpans().Len(); j++ {
+			spans := resourceSpans.ScopeSpans().At(j).Spans()
+
+			for k := 0; k < spans.Len(); k++ {
+				processAttributes(resourceAttributes, spans.At(k).Attributes())
+			}
+		}
+	}
+}
+
+// processLogsAttributesByResource calls processAttributes once per resource
+// with the resource attributes and the attributes of all log records of the resource.
+func processLogsAttributesByResource(logs plog.Logs, processAttributes func(resourceAttributes pcommon.Map, recordsAttributes []pcommon.Map)) {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		resourceLogs := logs.ResourceLogs().At(i)
+		recordsAttributes := []pcommon.Map{}
+
+		for j := 0; j < resourceLogs.ScopeLogs().Len(); j++ {
+			logRecords := resourceLogs.ScopeLogs().At(j).LogRecords()
+
+			for k := 0; k < logRecords.Len(); k++ {
+				recordsAttributes = append(recordsAttributes, logRecords.At(k).Attributes())
+			}
+		}
+
+		processAttributes(resourceLogs.Resource().Attributes(), recordsAttributes)
+	}
+}
+
+// processMetricsAttributesByResource calls processAttributes once per resource
+// with the resource attributes and the attributes of all data points of the resource.
+func processMetricsAttributesByResource(metrics pmetric.Metrics, processAttributes func(resourceAttributes pcommon.Map, recordsAttributes []pcommon.Map)) {
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		resourceMetrics := metrics.ResourceMetrics().At(i)
+		recordsAttributes := []pcommon.Map{}
+
+		for j := 0; j < resourceMetrics.ScopeMetrics().Len(); j++ {
+			metricsSlice := resourceMetrics.ScopeMetrics().At(j).Metrics()
+
+			for k := 0; k < metricsSlice.Len(); k++ {
+				processDataPointsAttributes(metricsSlice.At(k), func(attributes pcommon.Map) {
+					recordsAttributes = append(recordsAttributes, attributes)
+				})
+			}
+		}
+
+		processAttributes(resourceMetrics.Resource().Attributes(), recordsAttributes)
+	}
+}
+
+// processTracesAttributesByResource calls processAttributes once per resource
+// with the resource attributes and the attributes of all spans of the resource.
+func processTracesAttributesByResource(traces ptrace.Traces, processAttributes func(resourceAttributes pcommon.Map, recordsAttributes []pcommon.Map)) {
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		resourceSpans := traces.ResourceSpans().At(i)
+		recordsAttributes := []pcommon.Map{}
+
+		for j := 0; j < resourceSpans.ScopeSpans().Len(); j++ {
+			spans := resourceSpans.ScopeSpans().At(j).Spans()
+
+			for k := 0; k < spans.Len(); k++ {
+				recordsAttributes = append(recordsAttributes, spans.At(k).Attributes())
+			}
+		}
+
+		processAttributes(resourceSpans.Resource().Attributes(), recordsAttributes)
+	}
+}
+
+// processDataPointsAttributes calls processAttributes on attributes of all data points of the metric.
+func processDataPointsAttributes(metric pmetric.Metric, processAttributes func(pcommon.Map)) {
+	attributes, _ := dataPointsAttributes(metric)
+	for _, dataPointAttributes := range attributes {
+		processAttributes(dataPointAttributes)
+	}
+}
+
+// dataPointsAttributes returns attributes of all data points of the metric.
+// It returns false for data types it doesn't know, whose data points are then left unprocessed.
+func dataPointsAttributes(metric pmetric.Metric) ([]pcommon.Map, bool) {
+	var attributes []pcommon.Map
+
+	switch metric.DataType() {
+	case pmetric.MetricDataTypeNone:
+		// The metric has no data points.
+	case pmetric.MetricDataTypeGauge:
+		dataPoints := metric.Gauge().DataPoints()
+		attributes = make([]pcommon.Map, 0, dataPoints.Len())
+		for i := 0; i < dataPoints.Len(); i++ {
+			attributes = append(attributes, dataPoints.At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeSum:
+		dataPoints := metric.Sum().DataPoints()
+		attributes = make([]pcommon.Map, 0, dataPoints.Len())
+		for i := 0; i < dataPoints.Len(); i++ {
+			attributes = append(attributes, dataPoints.At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeHistogram:
+		dataPoints := metric.Histogram().DataPoints()
+		attributes = make([]pcommon.Map, 0, dataPoints.Len())
+		for i := 0; i < dataPoints.Len(); i++ {
+			attributes = append(attributes, dataPoints.At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dataPoints := metric.ExponentialHistogram().DataPoints()
+		attributes = make([]pcommon.Map, 0, dataPoints.Len())
+		for i := 0; i < dataPoints.Len(); i++ {
+			attributes = append(attributes, dataPoints.At(i).Attributes())
+		}
+	case pmetric.MetricDataTypeSummary:
+		dataPoints := metric.Summary().DataPoints()
+		attributes = make([]pcommon.Map, 0, dataPoints.Len())
+		for i := 0; i < dataPoints.Len(); i++ {
+			attributes = append(attributes, dataPoints.At(i).Attributes())
+		}
+	default:
+		return nil, false
+	}
+
+	return attributes, true
+}
+
+// processMetricsExemplarsAttributes calls processAttributes on filtered attributes of exemplars of all data points.
+func processMetricsExemplarsAttributes(metrics pmetric.Metrics, processAttributes func(pcommon.Map)) {
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		scopeMetricsSlice := metrics.ResourceMetrics().At(i).ScopeMetrics()
+
+		for j := 0; j < scopeMetricsSlice.Len(); j++ {
+			metricsSlice := scopeMetricsSlice.At(j).Metrics()
+
+			for k := 0; k < metricsSlice.Len(); k++ {
+				processExemplarsAttributes(metricsSlice.At(k), processAttributes)
+			}
+		}
+	}
+}
+
+// processExemplarsAttributes calls processAttributes on filtered attributes of exemplars of the metric's data points.
+// Summary data points have no exemplars.
+func processExemplarsAttributes(metric pmetric.Metric, processAttributes func(pcommon.Map)) {
+	processExemplars := func(exemplars pmetric.ExemplarSlice) {
+		for i := 0; i < exemplars.Len(); i++ {
+			processAttributes(exemplars.At(i).FilteredAttributes())
+		}
+	}
+
+	switch metric.DataType() {
+	case pmetric.MetricDataTypeGauge:
+		dataPoints := metric.Gauge().DataPoints()
+		for i := 0; i < dataPoints.Len(); i++ {
+			processExemplars(dataPoints.At(i).Exemplars())
+		}
+	case pmetric.MetricDataTypeSum:
+		dataPoints := metric.Sum().DataPoints()
+		for i := 0; i < dataPoints.Len(); i++ {
+			processExemplars(dataPoints.At(i).Exemplars())
+		}
+	case pmetric.MetricDataTypeHistogram:
+		dataPoints := metric.Histogram().DataPoints()
+		for i := 0; i < dataPoints.Len(); i++ {
+			processExemplars(dataPoints.At(i).Exemplars())
+		}
+	case pmetric.MetricDataTypeExponentialHistogram:
+		dataPoints := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dataPoints.Len(); i++ {
+			processExemplars(dataPoints.At(i).Exemplars())
+		}
+	}
+}
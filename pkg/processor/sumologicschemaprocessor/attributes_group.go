@@ -0,0 +1,188 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// attributesSubprocessor is a sub-processor which processes resource attributes and record attributes
+// of all signals the same way, one attribute map at a time.
+// processAttributes returns the number of attributes it removed from the map. Attributes which were only renamed
+// are not counted, but attributes overwritten by a renamed one are.
+type attributesSubprocessor interface {
+	SubProcessor
+	processAttributes(pcommon.Map) int
+}
+
+// runAttributesSubprocessorOnLogs runs the attributes sub-processor on resource attributes and log record attributes,
+// and records the number of attributes it removed.
+func runAttributesSubprocessorOnLogs(subprocessor attributesSubprocessor, logs plog.Logs) {
+	removed := 0
+	processLogsAttributes(logs, func(attributes pcommon.Map) {
+		removed += subprocessor.processAttributes(attributes)
+	})
+	recordAttributesRemoved(subprocessor.ConfigPropertyName(), signalLogs, removed)
+}
+
+// runAttributesSubprocessorOnMetrics runs the attributes sub-processor on resource attributes and data point attributes,
+// and records the number of attributes it removed.
+func runAttributesSubprocessorOnMetrics(subprocessor attributesSubprocessor, metrics pmetric.Metrics) {
+	removed := 0
+	processMetricsAttributes(metrics, func(attributes pcommon.Map) {
+		removed += subprocessor.processAttributes(attributes)
+	})
+	recordAttributesRemoved(subprocessor.ConfigPropertyName(), signalMetrics, removed)
+}
+
+// runAttributesSubprocessorOnTraces runs the attributes sub-processor on resource attributes and span attributes,
+// and records the number of attributes it removed.
+func runAttributesSubprocessorOnTraces(subprocessor attributesSubprocessor, traces ptrace.Traces) {
+	removed := 0
+	processTracesAttributes(traces, func(attributes pcommon.Map) {
+		removed += subprocessor.processAttributes(attributes)
+	})
+	recordAttributesRemoved(subprocessor.ConfigPropertyName(), signalTraces, removed)
+}
+
+// attributesSubprocessorGroup runs consecutive attributes sub-processors in a single traversal of the data.
+// Every visited attribute map is passed to all the sub-processors, in order.
+// As attributes sub-processors only modify the map they are given, this gives the same result
+// as running them one after another.
+//
+// The group also records the number of attributes removed by each of the sub-processors
+// and, if enabled, the time each of them spent on a batch.
+type attributesSubprocessorGroup struct {
+	subprocessors []attributesSubprocessor
+	telemetry     *processorTelemetry
+	// includeExemplars defines whether filtered attributes of exemplars are processed too.
+	includeExemplars bool
+}
+
+// groupAttributesSubprocessors replaces every run of consecutive attributes sub-processors
+// with an attributesSubprocessorGroup. The order of the sub-processors is preserved.
+func groupAttributesSubprocessors(subprocessors []SubProcessor, telemetry *processorTelemetry, includeExemplars bool) []SubProcessor {
+	grouped := make([]SubProcessor, 0, len(subprocessors))
+	run := []attributesSubprocessor{}
+
+	flush := func() {
+		if len(run) > 0 {
+			grouped = append(grouped, &attributesSubprocessorGroup{
+				subprocessors:    run,
+				telemetry:        telemetry,
+				includeExemplars: includeExemplars,
+			})
+		}
+		run = []attributesSubprocessor{}
+	}
+
+	for _, subprocessor := range subprocessors {
+		if attributesProcessor, ok := subprocessor.(attributesSubprocessor); ok {
+			run = append(run, attributesProcessor)
+			continue
+		}
+		flush()
+		grouped = append(grouped, subprocessor)
+	}
+	flush()
+
+	return grouped
+}
+
+// attributesGroupStats holds statistics of a single batch, indexed like the sub-processors of the group.
+type attributesGroupStats struct {
+	removed []int
+	// durations is nil unless the match duration is recorded.
+	durations []time.Duration
+}
+
+func (group *attributesSubprocessorGroup) newStats() *attributesGroupStats {
+	stats := &attributesGroupStats{removed: make([]int, len(group.subprocessors))}
+	if group.telemetry.recordsMatchDuration() {
+		stats.durations = make([]time.Duration, len(group.subprocessors))
+	}
+	return stats
+}
+
+func (group *attributesSubprocessorGroup) ProcessLogs(logs plog.Logs) error {
+	stats := group.newStats()
+	processLogsAttributes(logs, func(attributes pcommon.Map) {
+		group.processAttributes(attributes, stats)
+	})
+	group.recordStats(signalLogs, stats)
+	return nil
+}
+
+func (group *attributesSubprocessorGroup) ProcessMetrics(metrics pmetric.Metrics) error {
+	stats := group.newStats()
+	processAttributes := func(attributes pcommon.Map) {
+		group.processAttributes(attributes, stats)
+	}
+	processMetricsAttributes(metrics, processAttributes)
+	if group.includeExemplars {
+		processMetricsExemplarsAttributes(metrics, processAttributes)
+	}
+	group.recordStats(signalMetrics, stats)
+	return nil
+}
+
+func (group *attributesSubprocessorGroup) ProcessTraces(traces ptrace.Traces) error {
+	stats := group.newStats()
+	processTracesAttributes(traces, func(attributes pcommon.Map) {
+		group.processAttributes(attributes, stats)
+	})
+	group.recordStats(signalTraces, stats)
+	return nil
+}
+
+func (group *attributesSubprocessorGroup) IsEnabled() bool {
+	return true
+}
+
+func (group *attributesSubprocessorGroup) ConfigPropertyName() string {
+	names := make([]string, 0, len(group.subprocessors))
+	for _, subprocessor := range group.subprocessors {
+		names = append(names, subprocessor.ConfigPropertyName())
+	}
+	return strings.Join(names, ", ")
+}
+
+// processAttributes runs all the sub-processors on the attributes and adds the statistics of each of them to stats.
+func (group *attributesSubprocessorGroup) processAttributes(attributes pcommon.Map, stats *attributesGroupStats) {
+	for i, subprocessor := range group.subprocessors {
+		if stats.durations != nil {
+			start := time.Now()
+			stats.removed[i] += subprocessor.processAttributes(attributes)
+			stats.durations[i] += time.Since(start)
+		} else {
+			stats.removed[i] += subprocessor.processAttributes(attributes)
+		}
+	}
+}
+
+func (group *attributesSubprocessorGroup) recordStats(signal string, stats *attributesGroupStats) {
+	for i, subprocessor := range group.subprocessors {
+		recordAttributesRemoved(subprocessor.ConfigPropertyName(), signal, stats.removed[i])
+		if stats.durations != nil {
+			group.telemetry.recordMatchDuration(subprocessor.ConfigPropertyName(), signal, stats.durations[i])
+		}
+	}
+}
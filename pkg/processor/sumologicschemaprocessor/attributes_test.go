@@ -0,0 +1,89 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestDataPointsAttributes(t *testing.T) {
+	testCases := []struct {
+		dataType        pmetric.MetricDataType
+		appendDataPoint func(pmetric.Metric)
+	}{
+		{
+			dataType:        pmetric.MetricDataTypeGauge,
+			appendDataPoint: func(m pmetric.Metric) { m.Gauge().DataPoints().AppendEmpty() },
+		},
+		{
+			dataType:        pmetric.MetricDataTypeSum,
+			appendDataPoint: func(m pmetric.Metric) { m.Sum().DataPoints().AppendEmpty() },
+		},
+		{
+			dataType:        pmetric.MetricDataTypeHistogram,
+			appendDataPoint: func(m pmetric.Metric) { m.Histogram().DataPoints().AppendEmpty() },
+		},
+		{
+			dataType:        pmetric.MetricDataTypeExponentialHistogram,
+			appendDataPoint: func(m pmetric.Metric) { m.ExponentialHistogram().DataPoints().AppendEmpty() },
+		},
+		{
+			dataType:        pmetric.MetricDataTypeSummary,
+			appendDataPoint: func(m pmetric.Metric) { m.Summary().DataPoints().AppendEmpty() },
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.dataType.String(), func(t *testing.T) {
+			metric := pmetric.NewMetric()
+			metric.SetDataType(testCase.dataType)
+			testCase.appendDataPoint(metric)
+			testCase.appendDataPoint(metric)
+
+			attributes, supported := dataPointsAttributes(metric)
+			require.True(t, supported)
+			require.Len(t, attributes, 2)
+
+			attributes[1].InsertString("key", "value")
+			processed := []map[string]interface{}{}
+			processDataPointsAttributes(metric, func(attributes pcommon.Map) {
+				processed = append(processed, attributes.AsRaw())
+			})
+			assert.Equal(t, []map[string]interface{}{{}, {"key": "value"}}, processed)
+		})
+	}
+}
+
+func TestDataPointsAttributesEmptyMetric(t *testing.T) {
+	attributes, supported := dataPointsAttributes(pmetric.NewMetric())
+	assert.True(t, supported)
+	assert.Empty(t, attributes)
+}
+
+// TestDataPointsAttributesAllDataTypes fails when pdata adds a metric data type
+// which dataPointsAttributes doesn't handle yet.
+func TestDataPointsAttributesAllDataTypes(t *testing.T) {
+	for dataType := pmetric.MetricDataTypeNone; dataType.String() != ""; dataType++ {
+		metric := pmetric.NewMetric()
+		metric.SetDataType(dataType)
+		_, supported := dataPointsAttributes(metric)
+		assert.True(t, supported, "data type %s is not supported", dataType)
+	}
+}
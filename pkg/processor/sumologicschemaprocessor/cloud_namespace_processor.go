@@ -25,43 +25,64 @@ import (
 // cloudNamespaceProcessor adds the `cloud.namespace` resource attribute to logs, metrics and traces.
 type cloudNamespaceProcessor struct {
 	addCloudNamespace bool
+	attributeName     string
+	// mappings maps `cloud.platform` values to `cloud.namespace` values, taking precedence over the built-in mapping.
+	mappings map[string]string
 }
 
 const (
-	cloudNamespaceAttributeName = "cloud.namespace"
-	cloudNamespaceAwsEc2        = "aws/ec2"
-	cloudNamespaceAwsEcs        = "ecs"
-	cloudNamespaceAwsBeanstalk  = "ElasticBeanstalk"
+	defaultCloudNamespaceAttributeName = "cloud.namespace"
+	cloudNamespaceAwsEc2               = "aws/ec2"
+	cloudNamespaceAwsEcs               = "ecs"
+	cloudNamespaceAwsBeanstalk         = "ElasticBeanstalk"
+	cloudNamespaceAzureVM              = "azure/vm"
+	cloudNamespaceAzureFunction        = "azure/functions"
+	cloudNamespaceGcpGce               = "gcp/gce"
+	cloudNamespaceGcpGke               = "gcp/gke"
 )
 
-func newCloudNamespaceProcessor(addCloudNamespace bool) (*cloudNamespaceProcessor, error) {
+func newCloudNamespaceProcessor(addCloudNamespace bool, attributeName string, mappings map[string]string) (*cloudNamespaceProcessor, error) {
 	return &cloudNamespaceProcessor{
 		addCloudNamespace: addCloudNamespace,
+		attributeName:     attributeName,
+		mappings:          mappings,
 	}, nil
 }
 
-func (*cloudNamespaceProcessor) processLogs(logs plog.Logs) error {
+func (proc *cloudNamespaceProcessor) ProcessLogs(logs plog.Logs) error {
+	if !proc.addCloudNamespace {
+		return nil
+	}
+
 	for i := 0; i < logs.ResourceLogs().Len(); i++ {
-		addCloudNamespaceAttribute(logs.ResourceLogs().At(i).Resource().Attributes())
+		proc.addCloudNamespaceAttribute(logs.ResourceLogs().At(i).Resource().Attributes())
 	}
 	return nil
 }
 
-func (*cloudNamespaceProcessor) processMetrics(metrics pmetric.Metrics) error {
+func (proc *cloudNamespaceProcessor) ProcessMetrics(metrics pmetric.Metrics) error {
+	if !proc.addCloudNamespace {
+		return nil
+	}
+
 	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
-		addCloudNamespaceAttribute(metrics.ResourceMetrics().At(i).Resource().Attributes())
+		proc.addCloudNamespaceAttribute(metrics.ResourceMetrics().At(i).Resource().Attributes())
 	}
 	return nil
 }
 
-func (*cloudNamespaceProcessor) processTraces(traces ptrace.Traces) error {
+func (proc *cloudNamespaceProcessor) ProcessTraces(traces ptrace.Traces) error {
+	if !proc.addCloudNamespace {
+		return nil
+	}
+
 	for i := 0; i < traces.ResourceSpans().Len(); i++ {
-		addCloudNamespaceAttribute(traces.ResourceSpans().At(i).Resource().Attributes())
+		proc.addCloudNamespaceAttribute(traces.ResourceSpans().At(i).Resource().Attributes())
 	}
 	return nil
 }
 
-func (proc *cloudNamespaceProcessor) isEnabled() bool {
+func (proc *cloudNamespaceProcessor) IsEnabled() bool {
 	return proc.addCloudNamespace
 }
 
@@ -72,19 +93,34 @@ func (*cloudNamespaceProcessor) ConfigPropertyName() string {
 // addCloudNamespaceAttribute adds the `cloud.namespace` attribute
 // to a collection of attributes that already contains a `cloud.platform` attribute.
 // It does not add the `cloud.namespace` attribute for all `cloud.platform` values,
-// but only for a few specific ones - namely AWS EC2, AWS ECS, and AWS Elastic Beanstalk.
-func addCloudNamespaceAttribute(attributes pcommon.Map) {
+// but only for the user-provided mappings and a few specific ones - namely AWS EC2, AWS ECS,
+// AWS Elastic Beanstalk, Azure VM, Azure Functions, GCP Compute Engine and GCP Kubernetes Engine.
+func (proc *cloudNamespaceProcessor) addCloudNamespaceAttribute(attributes pcommon.Map) {
 	cloudPlatformAttributeValue, found := attributes.Get(conventions.AttributeCloudPlatform)
 	if !found {
 		return
 	}
 
+	attributeName := proc.attributeName
+	if cloudNamespace, ok := proc.mappings[cloudPlatformAttributeValue.StringVal()]; ok {
+		attributes.InsertString(attributeName, cloudNamespace)
+		return
+	}
+
 	switch cloudPlatformAttributeValue.StringVal() {
 	case conventions.AttributeCloudPlatformAWSEC2:
-		attributes.InsertString(cloudNamespaceAttributeName, cloudNamespaceAwsEc2)
+		attributes.InsertString(attributeName, cloudNamespaceAwsEc2)
 	case conventions.AttributeCloudPlatformAWSECS:
-		attributes.InsertString(cloudNamespaceAttributeName, cloudNamespaceAwsEcs)
+		attributes.InsertString(attributeName, cloudNamespaceAwsEcs)
 	case conventions.AttributeCloudPlatformAWSElasticBeanstalk:
-		attributes.InsertString(cloudNamespaceAttributeName, cloudNamespaceAwsBeanstalk)
+		attributes.InsertString(attributeName, cloudNamespaceAwsBeanstalk)
+	case conventions.AttributeCloudPlatformAzureVM:
+		attributes.InsertString(attributeName, cloudNamespaceAzureVM)
+	case conventions.AttributeCloudPlatformAzureFunctions:
+		attributes.InsertString(attributeName, cloudNamespaceAzureFunction)
+	case conventions.AttributeCloudPlatformGCPComputeEngine:
+		attributes.InsertString(attributeName, cloudNamespaceGcpGce)
+	case conventions.AttributeCloudPlatformGCPKubernetesEngine:
+		attributes.InsertString(attributeName, cloudNamespaceGcpGke)
 	}
 }
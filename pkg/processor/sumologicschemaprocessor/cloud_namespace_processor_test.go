@@ -0,0 +1,119 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestAddCloudNamespaceAttribute(t *testing.T) {
+	testCases := []struct {
+		cloudPlatform  string
+		cloudNamespace string
+	}{
+		{cloudPlatform: "aws_ec2", cloudNamespace: "aws/ec2"},
+		{cloudPlatform: "aws_ecs", cloudNamespace: "ecs"},
+		{cloudPlatform: "aws_elastic_beanstalk", cloudNamespace: "ElasticBeanstalk"},
+		{cloudPlatform: "aws_lambda", cloudNamespace: ""},
+		{cloudPlatform: "aws_eks", cloudNamespace: ""},
+		{cloudPlatform: "azure_vm", cloudNamespace: "azure/vm"},
+		{cloudPlatform: "azure_functions", cloudNamespace: "azure/functions"},
+		{cloudPlatform: "azure_aks", cloudNamespace: ""},
+		{cloudPlatform: "gcp_compute_engine", cloudNamespace: "gcp/gce"},
+		{cloudPlatform: "gcp_kubernetes_engine", cloudNamespace: "gcp/gke"},
+		{cloudPlatform: "gcp_app_engine", cloudNamespace: ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.cloudPlatform, func(t *testing.T) {
+			processor, err := newCloudNamespaceProcessor(true, "cloud.namespace", nil)
+			require.NoError(t, err)
+
+			attributes := pcommon.NewMap()
+			attributes.InsertString("cloud.platform", testCase.cloudPlatform)
+
+			processor.addCloudNamespaceAttribute(attributes)
+
+			cloudNamespace, found := attributes.Get("cloud.namespace")
+			if testCase.cloudNamespace == "" {
+				assert.False(t, found)
+			} else {
+				assert.True(t, found)
+				assert.Equal(t, testCase.cloudNamespace, cloudNamespace.StringVal())
+			}
+		})
+	}
+}
+
+func TestAddCloudNamespaceAttributeWithoutCloudPlatform(t *testing.T) {
+	processor, err := newCloudNamespaceProcessor(true, "cloud.namespace", nil)
+	require.NoError(t, err)
+
+	attributes := pcommon.NewMap()
+
+	processor.addCloudNamespaceAttribute(attributes)
+
+	assert.Equal(t, 0, attributes.Len())
+}
+
+func TestAddCloudNamespaceAttributeWithCustomName(t *testing.T) {
+	processor, err := newCloudNamespaceProcessor(true, "namespace", nil)
+	require.NoError(t, err)
+
+	attributes := pcommon.NewMap()
+	attributes.InsertString("cloud.platform", "aws_ec2")
+
+	processor.addCloudNamespaceAttribute(attributes)
+
+	_, found := attributes.Get("cloud.namespace")
+	assert.False(t, found)
+	cloudNamespace, found := attributes.Get("namespace")
+	assert.True(t, found)
+	assert.Equal(t, "aws/ec2", cloudNamespace.StringVal())
+}
+
+func TestAddCloudNamespaceAttributeWithMappings(t *testing.T) {
+	processor, err := newCloudNamespaceProcessor(true, "cloud.namespace", map[string]string{
+		"aws_ec2":          "custom/ec2",
+		"private_platform": "private",
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		cloudPlatform  string
+		cloudNamespace string
+	}{
+		{cloudPlatform: "aws_ec2", cloudNamespace: "custom/ec2"},
+		{cloudPlatform: "private_platform", cloudNamespace: "private"},
+		{cloudPlatform: "aws_ecs", cloudNamespace: "ecs"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.cloudPlatform, func(t *testing.T) {
+			attributes := pcommon.NewMap()
+			attributes.InsertString("cloud.platform", testCase.cloudPlatform)
+
+			processor.addCloudNamespaceAttribute(attributes)
+
+			cloudNamespace, found := attributes.Get("cloud.namespace")
+			assert.True(t, found)
+			assert.Equal(t, testCase.cloudNamespace, cloudNamespace.StringVal())
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const (
+	coerceTypeInt    = "int"
+	coerceTypeDouble = "double"
+	coerceTypeBool   = "bool"
+	coerceTypeAuto   = "auto"
+)
+
+// CoerceAttributesConfig configures the coerce_attributes sub-processor.
+type CoerceAttributesConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Patterns are attribute keys to coerce. The `*` character matches any sequence of characters.
+	Patterns []string `mapstructure:"patterns"`
+	// WildcardOptions change how Patterns are matched.
+	WildcardOptions `mapstructure:",squash"`
+	// Type is one of `int`, `double`, `bool` or `auto`.
+	Type string `mapstructure:"type"`
+}
+
+// coerceAttributesProcessor converts string attribute values to typed values.
+type coerceAttributesProcessor struct {
+	logger       *zap.Logger
+	enabled      bool
+	targetType   string
+	regexes      []*regexp.Regexp
+	maxKeyLength int
+}
+
+func newCoerceAttributesProcessor(config *CoerceAttributesConfig, logger *zap.Logger) (*coerceAttributesProcessor, error) {
+	if err := validateCoerceType(config.Type); err != nil {
+		return nil, err
+	}
+
+	regexes, err := compileWildcards(config.Patterns, config.WildcardOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &coerceAttributesProcessor{
+		logger:     logger,
+		enabled:    config.Enabled,
+		targetType: config.Type,
+		regexes:    regexes,
+	}, nil
+}
+
+func validateCoerceType(targetType string) error {
+	switch targetType {
+	case coerceTypeInt, coerceTypeDouble, coerceTypeBool, coerceTypeAuto:
+		return nil
+	default:
+		return fmt.Errorf("invalid coerce type: %q", targetType)
+	}
+}
+
+func (proc *coerceAttributesProcessor) ProcessLogs(logs plog.Logs) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnLogs(proc, logs)
+	}
+	return nil
+}
+
+func (proc *coerceAttributesProcessor) ProcessMetrics(metrics pmetric.Metrics) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnMetrics(proc, metrics)
+	}
+	return nil
+}
+
+func (proc *coerceAttributesProcessor) ProcessTraces(traces ptrace.Traces) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnTraces(proc, traces)
+	}
+	return nil
+}
+
+func (proc *coerceAttributesProcessor) IsEnabled() bool {
+	return proc.enabled
+}
+
+func (*coerceAttributesProcessor) ConfigPropertyName() string {
+	return "coerce_attributes"
+}
+
+func (proc *coerceAttributesProcessor) setMaxKeyLength(maxKeyLength int) {
+	proc.maxKeyLength = maxKeyLength
+}
+
+func (proc *coerceAttributesProcessor) processAttributes(attributes pcommon.Map) int {
+	attributes.Range(func(key string, value pcommon.Value) bool {
+		if value.Type() != pcommon.ValueTypeString || !matchesAnyKeyRegex(proc.regexes, key, proc.maxKeyLength) {
+			return true
+		}
+
+		if !coerceValue(value, proc.targetType) {
+			proc.logger.Debug(
+				"Failed to coerce attribute value",
+				zap.String("key", key),
+				zap.String("value", value.StringVal()),
+				zap.String("type", proc.targetType),
+			)
+		}
+		return true
+	})
+	return 0
+}
+
+// coerceValue parses a string value in place. It returns false if the value can't be parsed as targetType.
+func coerceValue(value pcommon.Value, targetType string) bool {
+	str := value.StringVal()
+
+	if targetType == coerceTypeInt || targetType == coerceTypeAuto {
+		if intVal, err := strconv.ParseInt(str, 10, 64); err == nil {
+			value.SetIntVal(intVal)
+			return true
+		}
+	}
+
+	if targetType == coerceTypeDouble || targetType == coerceTypeAuto {
+		if doubleVal, err := strconv.ParseFloat(str, 64); err == nil {
+			value.SetDoubleVal(doubleVal)
+			return true
+		}
+	}
+
+	if targetType == coerceTypeBool || targetType == coerceTypeAuto {
+		if boolVal, err := strconv.ParseBool(str); err == nil {
+			value.SetBoolVal(boolVal)
+			return true
+		}
+	}
+
+	return false
+}
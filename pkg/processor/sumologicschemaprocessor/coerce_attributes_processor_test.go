@@ -0,0 +1,149 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+)
+
+func TestCoerceAttributes(t *testing.T) {
+	testCases := []struct {
+		name       string
+		targetType string
+		input      map[string]interface{}
+		expected   map[string]interface{}
+	}{
+		{
+			name:       "coerces to int",
+			targetType: "int",
+			input: map[string]interface{}{
+				"a": "42",
+				"b": "-7",
+				"c": "3.14",
+				"d": "true",
+			},
+			expected: map[string]interface{}{
+				"a": int64(42),
+				"b": int64(-7),
+				"c": "3.14",
+				"d": "true",
+			},
+		},
+		{
+			name:       "coerces to double",
+			targetType: "double",
+			input: map[string]interface{}{
+				"a": "42",
+				"b": "3.14",
+				"c": "pi",
+			},
+			expected: map[string]interface{}{
+				"a": float64(42),
+				"b": 3.14,
+				"c": "pi",
+			},
+		},
+		{
+			name:       "coerces to bool",
+			targetType: "bool",
+			input: map[string]interface{}{
+				"a": "true",
+				"b": "FALSE",
+				"c": "yes",
+			},
+			expected: map[string]interface{}{
+				"a": true,
+				"b": false,
+				"c": "yes",
+			},
+		},
+		{
+			name:       "coerces to the most specific type",
+			targetType: "auto",
+			input: map[string]interface{}{
+				"a": "42",
+				"b": "3.14",
+				"c": "true",
+				"d": "hello",
+				"e": "",
+			},
+			expected: map[string]interface{}{
+				"a": int64(42),
+				"b": 3.14,
+				"c": true,
+				"d": "hello",
+				"e": "",
+			},
+		},
+		{
+			name:       "leaves non-string values unchanged",
+			targetType: "auto",
+			input: map[string]interface{}{
+				"a": 1.5,
+				"b": false,
+			},
+			expected: map[string]interface{}{
+				"a": 1.5,
+				"b": false,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			processor, err := newCoerceAttributesProcessor(&CoerceAttributesConfig{
+				Enabled:  true,
+				Patterns: []string{"*"},
+				Type:     testCase.targetType,
+			}, zap.NewNop())
+			require.NoError(t, err)
+
+			attributes := pcommon.NewMapFromRaw(testCase.input)
+			processor.processAttributes(attributes)
+
+			assert.Equal(t, testCase.expected, attributes.AsRaw())
+		})
+	}
+}
+
+func TestCoerceAttributesOnlyMatchingKeys(t *testing.T) {
+	processor, err := newCoerceAttributesProcessor(&CoerceAttributesConfig{
+		Enabled:  true,
+		Patterns: []string{"http.*"},
+		Type:     "auto",
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	attributes := pcommon.NewMapFromRaw(map[string]interface{}{
+		"http.status_code": "200",
+		"version":          "2",
+	})
+	processor.processAttributes(attributes)
+
+	assert.Equal(t, map[string]interface{}{
+		"http.status_code": int64(200),
+		"version":          "2",
+	}, attributes.AsRaw())
+}
+
+func TestCoerceAttributesInvalidType(t *testing.T) {
+	_, err := newCoerceAttributesProcessor(&CoerceAttributesConfig{Type: "string"}, zap.NewNop())
+	assert.EqualError(t, err, `invalid coerce type: "string"`)
+}
@@ -0,0 +1,347 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// ConditionConfig defines an attribute a resource or a record has to carry to be processed by a sub-processor.
+type ConditionConfig struct {
+	// Attribute is the key of the attribute.
+	Attribute string `mapstructure:"attribute"`
+	// Value is the value the attribute has to be equal to.
+	Value string `mapstructure:"value"`
+	// Regex is a regular expression the attribute value has to match.
+	Regex string `mapstructure:"regex"`
+}
+
+// recordsRemovingSubprocessor is implemented by sub-processors which remove records.
+// They cannot be run with a condition, see conditionalSubprocessor.
+type recordsRemovingSubprocessor interface {
+	removesRecords()
+}
+
+// resourceWritingSubprocessor is implemented by sub-processors which change the resource based on its records
+// or the records based on their resource. They cannot be run with a condition either, because the resource
+// is only copied back if it satisfies the condition itself, see conditionalSubprocessor.
+type resourceWritingSubprocessor interface {
+	writesResource()
+}
+
+// metricsChangingSubprocessor is implemented by sub-processors which change metrics themselves, e.g. their names.
+// They cannot be run with a condition, because only the data point attributes of a metric whose data points
+// partially satisfy the condition are copied back, see conditionalSubprocessor.
+type metricsChangingSubprocessor interface {
+	changesMetrics()
+}
+
+type attributeCondition struct {
+	attribute string
+	value     string
+	regex     *regexp.Regexp
+}
+
+func newAttributeCondition(config ConditionConfig) (*attributeCondition, error) {
+	if err := validateConditionConfig(config); err != nil {
+		return nil, err
+	}
+
+	condition := &attributeCondition{
+		attribute: config.Attribute,
+		value:     config.Value,
+	}
+	if config.Regex != "" {
+		// Validation has already checked that the regex compiles.
+		condition.regex = regexp.MustCompile(config.Regex)
+	}
+	return condition, nil
+}
+
+func validateConditionConfig(config ConditionConfig) error {
+	if config.Attribute == "" {
+		return errors.New("attribute must not be empty")
+	}
+	if config.Value != "" && config.Regex != "" {
+		return errors.New("only one of value and regex can be set")
+	}
+	if _, err := regexp.Compile(config.Regex); err != nil {
+		return err
+	}
+	return nil
+}
+
+// matches returns true if the attributes contain the attribute with a matching value.
+func (condition *attributeCondition) matches(attributes pcommon.Map) bool {
+	value, found := attributes.Get(condition.attribute)
+	if !found {
+		return false
+	}
+
+	if condition.regex != nil {
+		return condition.regex.MatchString(value.AsString())
+	}
+	return value.AsString() == condition.value
+}
+
+// conditionalSubprocessor runs the wrapped sub-processor only on the resources and records which satisfy the condition.
+// A resource satisfies the condition if its attributes do. A record (log record, data point or span) satisfies
+// the condition if its attributes or the attributes of its resource do.
+//
+// The satisfying records are moved to a separate container, together with a copy of their resource,
+// and the wrapped sub-processor is run on that container. Afterwards, the records are moved back to their
+// original position, and the resource is copied back if it satisfies the condition.
+// Because of that, the wrapped sub-processor must not add or remove records.
+type conditionalSubprocessor struct {
+	SubProcessor
+	condition *attributeCondition
+}
+
+func newConditionalSubprocessor(subprocessor SubProcessor, condition *attributeCondition) *conditionalSubprocessor {
+	return &conditionalSubprocessor{
+		SubProcessor: subprocessor,
+		condition:    condition,
+	}
+}
+
+func (proc *conditionalSubprocessor) Start(ctx context.Context, host component.Host) error {
+	if starter, ok := proc.SubProcessor.(subprocessorStarter); ok {
+		return starter.Start(ctx, host)
+	}
+	return nil
+}
+
+func (proc *conditionalSubprocessor) Shutdown(ctx context.Context) error {
+	if shutdowner, ok := proc.SubProcessor.(subprocessorShutdowner); ok {
+		return shutdowner.Shutdown(ctx)
+	}
+	return nil
+}
+
+func (proc *conditionalSubprocessor) ProcessLogs(logs plog.Logs) error {
+	if !proc.IsEnabled() {
+		return nil
+	}
+
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		resourceLogs := logs.ResourceLogs().At(i)
+		resourceMatches := proc.condition.matches(resourceLogs.Resource().Attributes())
+
+		selected := plog.NewLogs()
+		selectedResourceLogs := selected.ResourceLogs().AppendEmpty()
+		resourceLogs.Resource().CopyTo(selectedResourceLogs.Resource())
+		selectedResourceLogs.SetSchemaUrl(resourceLogs.SchemaUrl())
+
+		// selectedIndexes holds the indexes of the selected log records for every scope.
+		selectedIndexes := make([][]int, resourceLogs.ScopeLogs().Len())
+		for j := 0; j < resourceLogs.ScopeLogs().Len(); j++ {
+			scopeLogs := resourceLogs.ScopeLogs().At(j)
+			selectedScopeLogs := selectedResourceLogs.ScopeLogs().AppendEmpty()
+			scopeLogs.Scope().CopyTo(selectedScopeLogs.Scope())
+			selectedScopeLogs.SetSchemaUrl(scopeLogs.SchemaUrl())
+
+			for k := 0; k < scopeLogs.LogRecords().Len(); k++ {
+				logRecord := scopeLogs.LogRecords().At(k)
+				if resourceMatches || proc.condition.matches(logRecord.Attributes()) {
+					logRecord.MoveTo(selectedScopeLogs.LogRecords().AppendEmpty())
+					selectedIndexes[j] = append(selectedIndexes[j], k)
+				}
+			}
+		}
+
+		err := proc.SubProcessor.ProcessLogs(selected)
+
+		if resourceMatches {
+			selectedResourceLogs.Resource().CopyTo(resourceLogs.Resource())
+		}
+		for j, indexes := range selectedIndexes {
+			selectedLogRecords := selectedResourceLogs.ScopeLogs().At(j).LogRecords()
+			for n, k := range indexes {
+				selectedLogRecords.At(n).MoveTo(resourceLogs.ScopeLogs().At(j).LogRecords().At(k))
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (proc *conditionalSubprocessor) ProcessMetrics(metrics pmetric.Metrics) error {
+	if !proc.IsEnabled() {
+		return nil
+	}
+
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		resourceMetrics := metrics.ResourceMetrics().At(i)
+		resourceMatches := proc.condition.matches(resourceMetrics.Resource().Attributes())
+
+		selected := pmetric.NewMetrics()
+		selectedResourceMetrics := selected.ResourceMetrics().AppendEmpty()
+		resourceMetrics.Resource().CopyTo(selectedResourceMetrics.Resource())
+		selectedResourceMetrics.SetSchemaUrl(resourceMetrics.SchemaUrl())
+
+		// selectedMetrics holds the selected metrics for every scope.
+		selectedMetrics := make([][]selectedMetric, resourceMetrics.ScopeMetrics().Len())
+		for j := 0; j < resourceMetrics.ScopeMetrics().Len(); j++ {
+			scopeMetrics := resourceMetrics.ScopeMetrics().At(j)
+			selectedScopeMetrics := selectedResourceMetrics.ScopeMetrics().AppendEmpty()
+			scopeMetrics.Scope().CopyTo(selectedScopeMetrics.Scope())
+			selectedScopeMetrics.SetSchemaUrl(scopeMetrics.SchemaUrl())
+
+			for k := 0; k < scopeMetrics.Metrics().Len(); k++ {
+				metric := scopeMetrics.Metrics().At(k)
+				if resourceMatches {
+					metric.MoveTo(selectedScopeMetrics.Metrics().AppendEmpty())
+					selectedMetrics[j] = append(selectedMetrics[j], selectedMetric{index: k, whole: true})
+					continue
+				}
+
+				matchingDataPoints := []int{}
+				dataPointsCount := 0
+				processDataPointsAttributes(metric, func(attributes pcommon.Map) {
+					if proc.condition.matches(attributes) {
+						matchingDataPoints = append(matchingDataPoints, dataPointsCount)
+					}
+					dataPointsCount++
+				})
+
+				switch {
+				case len(matchingDataPoints) == 0:
+					continue
+				case len(matchingDataPoints) == dataPointsCount:
+					metric.MoveTo(selectedScopeMetrics.Metrics().AppendEmpty())
+					selectedMetrics[j] = append(selectedMetrics[j], selectedMetric{index: k, whole: true})
+				default:
+					// The metric cannot be split, so it is copied and only the attributes
+					// of the matching data points are copied back.
+					metric.CopyTo(selectedScopeMetrics.Metrics().AppendEmpty())
+					selectedMetrics[j] = append(selectedMetrics[j], selectedMetric{index: k, dataPoints: matchingDataPoints})
+				}
+			}
+		}
+
+		err := proc.SubProcessor.ProcessMetrics(selected)
+
+		if resourceMatches {
+			selectedResourceMetrics.Resource().CopyTo(resourceMetrics.Resource())
+		}
+		for j, scopeSelectedMetrics := range selectedMetrics {
+			selectedMetricsSlice := selectedResourceMetrics.ScopeMetrics().At(j).Metrics()
+			for n, entry := range scopeSelectedMetrics {
+				metric := resourceMetrics.ScopeMetrics().At(j).Metrics().At(entry.index)
+				if entry.whole {
+					selectedMetricsSlice.At(n).MoveTo(metric)
+				} else {
+					copyDataPointsAttributes(selectedMetricsSlice.At(n), metric, entry.dataPoints)
+				}
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// selectedMetric describes a metric selected by conditionalSubprocessor.
+type selectedMetric struct {
+	index int
+	// whole is true if all data points of the metric were selected.
+	whole bool
+	// dataPoints holds the indexes of the selected data points if not all of them were selected.
+	dataPoints []int
+}
+
+// copyDataPointsAttributes copies the attributes of data points with given indexes from one metric to another.
+func copyDataPointsAttributes(from pmetric.Metric, to pmetric.Metric, indexes []int) {
+	fromAttributes := []pcommon.Map{}
+	processDataPointsAttributes(from, func(attributes pcommon.Map) {
+		fromAttributes = append(fromAttributes, attributes)
+	})
+	toAttributes := []pcommon.Map{}
+	processDataPointsAttributes(to, func(attributes pcommon.Map) {
+		toAttributes = append(toAttributes, attributes)
+	})
+
+	for _, index := range indexes {
+		if index < len(fromAttributes) && index < len(toAttributes) {
+			fromAttributes[index].CopyTo(toAttributes[index])
+		}
+	}
+}
+
+func (proc *conditionalSubprocessor) ProcessTraces(traces ptrace.Traces) error {
+	if !proc.IsEnabled() {
+		return nil
+	}
+
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		resourceSpans := traces.ResourceSpans().At(i)
+		resourceMatches := proc.condition.matches(resourceSpans.Resource().Attributes())
+
+		selected := ptrace.NewTraces()
+		selectedResourceSpans := selected.ResourceSpans().AppendEmpty()
+		resourceSpans.Resource().CopyTo(selectedResourceSpans.Resource())
+		selectedResourceSpans.SetSchemaUrl(resourceSpans.SchemaUrl())
+
+		// selectedIndexes holds the indexes of the selected spans for every scope.
+		selectedIndexes := make([][]int, resourceSpans.ScopeSpans().Len())
+		for j := 0; j < resourceSpans.ScopeSpans().Len(); j++ {
+			scopeSpans := resourceSpans.ScopeSpans().At(j)
+			selectedScopeSpans := selectedResourceSpans.ScopeSpans().AppendEmpty()
+			scopeSpans.Scope().CopyTo(selectedScopeSpans.Scope())
+			selectedScopeSpans.SetSchemaUrl(scopeSpans.SchemaUrl())
+
+			for k := 0; k < scopeSpans.Spans().Len(); k++ {
+				span := scopeSpans.Spans().At(k)
+				if resourceMatches || proc.condition.matches(span.Attributes()) {
+					span.MoveTo(selectedScopeSpans.Spans().AppendEmpty())
+					selectedIndexes[j] = append(selectedIndexes[j], k)
+				}
+			}
+		}
+
+		err := proc.SubProcessor.ProcessTraces(selected)
+
+		if resourceMatches {
+			selectedResourceSpans.Resource().CopyTo(resourceSpans.Resource())
+		}
+		for j, indexes := range selectedIndexes {
+			selectedSpans := selectedResourceSpans.ScopeSpans().At(j).Spans()
+			for n, k := range indexes {
+				selectedSpans.At(n).MoveTo(resourceSpans.ScopeSpans().At(j).Spans().At(k))
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,252 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newConditionalDropProcessor(t *testing.T, condition ConditionConfig) *conditionalSubprocessor {
+	dropProcessor, err := newDropAttributesProcessor(&DropAttributesConfig{
+		Enabled:  true,
+		Patterns: []string{"secret"},
+		MatchOn:  matchOnKey,
+	})
+	require.NoError(t, err)
+
+	attributeCondition, err := newAttributeCondition(condition)
+	require.NoError(t, err)
+
+	return newConditionalSubprocessor(dropProcessor, attributeCondition)
+}
+
+func TestAttributeCondition(t *testing.T) {
+	testCases := []struct {
+		name       string
+		config     ConditionConfig
+		attributes map[string]interface{}
+		expected   bool
+	}{
+		{
+			name:       "value matches",
+			config:     ConditionConfig{Attribute: "source", Value: "k8s"},
+			attributes: map[string]interface{}{"source": "k8s"},
+			expected:   true,
+		},
+		{
+			name:       "value does not match",
+			config:     ConditionConfig{Attribute: "source", Value: "k8s"},
+			attributes: map[string]interface{}{"source": "k8s-other"},
+			expected:   false,
+		},
+		{
+			name:       "attribute missing",
+			config:     ConditionConfig{Attribute: "source", Value: "k8s"},
+			attributes: map[string]interface{}{},
+			expected:   false,
+		},
+		{
+			name:       "regex matches",
+			config:     ConditionConfig{Attribute: "source", Regex: "^k8s"},
+			attributes: map[string]interface{}{"source": "k8s-other"},
+			expected:   true,
+		},
+		{
+			name:       "non-string value",
+			config:     ConditionConfig{Attribute: "port", Value: "80"},
+			attributes: map[string]interface{}{"port": int64(80)},
+			expected:   true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			condition, err := newAttributeCondition(testCase.config)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expected, condition.matches(pcommon.NewMapFromRaw(testCase.attributes)))
+		})
+	}
+}
+
+func TestAttributeConditionInvalidConfig(t *testing.T) {
+	_, err := newAttributeCondition(ConditionConfig{Attribute: "source", Value: "k8s", Regex: "k8s"})
+	assert.EqualError(t, err, "only one of value and regex can be set")
+
+	_, err = newAttributeCondition(ConditionConfig{Value: "k8s"})
+	assert.EqualError(t, err, "attribute must not be empty")
+
+	_, err = newAttributeCondition(ConditionConfig{Attribute: "source", Regex: "("})
+	assert.Error(t, err)
+}
+
+func TestConditionalSubprocessorLogs(t *testing.T) {
+	processor := newConditionalDropProcessor(t, ConditionConfig{Attribute: "source", Value: "k8s"})
+
+	logs := plog.NewLogs()
+	matchingResource := logs.ResourceLogs().AppendEmpty()
+	matchingResource.Resource().Attributes().InsertString("source", "k8s")
+	matchingResource.Resource().Attributes().InsertString("secret", "a")
+	matchingResource.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().InsertString("secret", "b")
+
+	otherResource := logs.ResourceLogs().AppendEmpty()
+	otherResource.Resource().Attributes().InsertString("secret", "c")
+	otherLogRecords := otherResource.ScopeLogs().AppendEmpty().LogRecords()
+	otherLogRecords.AppendEmpty().Attributes().InsertString("secret", "d")
+	matchingLogRecord := otherLogRecords.AppendEmpty()
+	matchingLogRecord.Attributes().InsertString("source", "k8s")
+	matchingLogRecord.Attributes().InsertString("secret", "e")
+	matchingLogRecord.Body().SetStringVal("body")
+	otherLogRecords.AppendEmpty().Attributes().InsertString("secret", "f")
+
+	require.NoError(t, processor.ProcessLogs(logs))
+
+	assert.Equal(t, map[string]interface{}{"source": "k8s"}, matchingResource.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{}, matchingResource.ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw())
+
+	assert.Equal(t, map[string]interface{}{"secret": "c"}, otherResource.Resource().Attributes().AsRaw())
+	require.Equal(t, 3, otherLogRecords.Len())
+	assert.Equal(t, map[string]interface{}{"secret": "d"}, otherLogRecords.At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"source": "k8s"}, otherLogRecords.At(1).Attributes().AsRaw())
+	assert.Equal(t, "body", otherLogRecords.At(1).Body().StringVal())
+	assert.Equal(t, map[string]interface{}{"secret": "f"}, otherLogRecords.At(2).Attributes().AsRaw())
+}
+
+func TestConditionalSubprocessorMetrics(t *testing.T) {
+	processor := newConditionalDropProcessor(t, ConditionConfig{Attribute: "source", Value: "k8s"})
+
+	metrics := pmetric.NewMetrics()
+	resourceMetrics := metrics.ResourceMetrics().AppendEmpty()
+	resourceMetrics.Resource().Attributes().InsertString("secret", "a")
+	metricsSlice := resourceMetrics.ScopeMetrics().AppendEmpty().Metrics()
+
+	wholeMetric := metricsSlice.AppendEmpty()
+	wholeMetric.SetName("whole")
+	wholeMetric.SetDataType(pmetric.MetricDataTypeGauge)
+	wholeDataPoint := wholeMetric.Gauge().DataPoints().AppendEmpty()
+	wholeDataPoint.Attributes().InsertString("source", "k8s")
+	wholeDataPoint.Attributes().InsertString("secret", "b")
+
+	partialMetric := metricsSlice.AppendEmpty()
+	partialMetric.SetName("partial")
+	partialMetric.SetDataType(pmetric.MetricDataTypeSum)
+	partialMetric.Sum().DataPoints().AppendEmpty().Attributes().InsertString("secret", "c")
+	partialDataPoint := partialMetric.Sum().DataPoints().AppendEmpty()
+	partialDataPoint.Attributes().InsertString("source", "k8s")
+	partialDataPoint.Attributes().InsertString("secret", "d")
+
+	otherMetric := metricsSlice.AppendEmpty()
+	otherMetric.SetName("other")
+	otherMetric.SetDataType(pmetric.MetricDataTypeGauge)
+	otherMetric.Gauge().DataPoints().AppendEmpty().Attributes().InsertString("secret", "e")
+
+	require.NoError(t, processor.ProcessMetrics(metrics))
+
+	assert.Equal(t, map[string]interface{}{"secret": "a"}, resourceMetrics.Resource().Attributes().AsRaw())
+	require.Equal(t, 3, metricsSlice.Len())
+	assert.Equal(t, "whole", metricsSlice.At(0).Name())
+	assert.Equal(t, map[string]interface{}{"source": "k8s"}, metricsSlice.At(0).Gauge().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, "partial", metricsSlice.At(1).Name())
+	assert.Equal(t, map[string]interface{}{"secret": "c"}, metricsSlice.At(1).Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"source": "k8s"}, metricsSlice.At(1).Sum().DataPoints().At(1).Attributes().AsRaw())
+	assert.Equal(t, "other", metricsSlice.At(2).Name())
+	assert.Equal(t, map[string]interface{}{"secret": "e"}, metricsSlice.At(2).Gauge().DataPoints().At(0).Attributes().AsRaw())
+}
+
+func TestConditionalSubprocessorPartialMetric(t *testing.T) {
+	// Custom sub-processors can change metrics under a condition, so the behaviour for partially
+	// satisfying metrics is defined: only changes to the attributes of the satisfying data points are kept.
+	translateProcessor, err := newTranslateMetricNamesProcessor(&TranslateMetricNamesConfig{
+		Enabled: true,
+		Mapping: map[string]string{"whole": "whole_renamed", "partial": "partial_renamed"},
+	})
+	require.NoError(t, err)
+	attributeCondition, err := newAttributeCondition(ConditionConfig{Attribute: "source", Value: "k8s"})
+	require.NoError(t, err)
+	processor := newConditionalSubprocessor(translateProcessor, attributeCondition)
+
+	metrics := pmetric.NewMetrics()
+	metricsSlice := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+
+	wholeMetric := metricsSlice.AppendEmpty()
+	wholeMetric.SetName("whole")
+	wholeMetric.SetDataType(pmetric.MetricDataTypeGauge)
+	wholeMetric.Gauge().DataPoints().AppendEmpty().Attributes().InsertString("source", "k8s")
+
+	partialMetric := metricsSlice.AppendEmpty()
+	partialMetric.SetName("partial")
+	partialMetric.SetDataType(pmetric.MetricDataTypeGauge)
+	partialMetric.Gauge().DataPoints().AppendEmpty().Attributes().InsertString("source", "k8s")
+	partialMetric.Gauge().DataPoints().AppendEmpty().Attributes().InsertString("source", "other")
+
+	require.NoError(t, processor.ProcessMetrics(metrics))
+
+	assert.Equal(t, "whole_renamed", metricsSlice.At(0).Name())
+	assert.Equal(t, "partial", metricsSlice.At(1).Name())
+	assert.Equal(t, 2, metricsSlice.At(1).Gauge().DataPoints().Len())
+}
+
+func TestConditionsMetricsChangingSubprocessor(t *testing.T) {
+	for _, name := range []string{"translate_metric_names", "translate_telegraf_attributes"} {
+		config := createDefaultConfig().(*Config)
+		config.TranslateMetricNames.Enabled = true
+		config.Conditions = map[string]ConditionConfig{
+			name: {Attribute: "source", Value: "k8s"},
+		}
+
+		_, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), config)
+		assert.EqualError(t, err, "conditions: "+name+": sub-processors which change metrics cannot have a condition")
+	}
+}
+
+func TestConditionalSubprocessorTraces(t *testing.T) {
+	processor := newConditionalDropProcessor(t, ConditionConfig{Attribute: "source", Regex: "k8s"})
+
+	traces := ptrace.NewTraces()
+	resourceSpans := traces.ResourceSpans().AppendEmpty()
+	resourceSpans.Resource().Attributes().InsertString("source", "k8s")
+	resourceSpans.Resource().Attributes().InsertString("secret", "a")
+	span := resourceSpans.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("span")
+	span.Attributes().InsertString("secret", "b")
+
+	otherResourceSpans := traces.ResourceSpans().AppendEmpty()
+	otherSpan := otherResourceSpans.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	otherSpan.Attributes().InsertString("secret", "c")
+
+	require.NoError(t, processor.ProcessTraces(traces))
+
+	assert.Equal(t, map[string]interface{}{"source": "k8s"}, resourceSpans.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{}, span.Attributes().AsRaw())
+	assert.Equal(t, "span", span.Name())
+	assert.Equal(t, map[string]interface{}{"secret": "c"}, otherSpan.Attributes().AsRaw())
+}
+
+func TestConditionsUnknownSubprocessor(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.Conditions = map[string]ConditionConfig{
+		"unknown_attributes": {Attribute: "source", Value: "k8s"},
+	}
+
+	_, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), config)
+	assert.EqualError(t, err, `conditions: unknown sub-processor "unknown_attributes"`)
+}
@@ -14,20 +14,185 @@
 
 package sumologicschemaprocessor
 
-import "go.opentelemetry.io/collector/config"
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.uber.org/multierr"
+)
 
 type Config struct {
 	config.ProcessorSettings `mapstructure:",squash"`
 
-	AddCloudNamespace           bool `mapstructure:"add_cloud_namespace"`
-	TranslateAttributes         bool `mapstructure:"translate_attributes"`
-	TranslateTelegrafAttributes bool `mapstructure:"translate_telegraf_attributes"`
+	AddCloudNamespace               bool              `mapstructure:"add_cloud_namespace"`
+	CloudNamespaceAttribute         string            `mapstructure:"cloud_namespace_attribute"`
+	CloudNamespaceMappings          map[string]string `mapstructure:"cloud_namespace_mappings"`
+	TranslateAttributes             bool              `mapstructure:"translate_attributes"`
+	TranslateAttributesExtra        map[string]string `mapstructure:"translate_attributes_extra"`
+	TranslateAttributesDirection    string            `mapstructure:"translate_attributes_direction"`
+	TranslateAttributesSkip         []string          `mapstructure:"translate_attributes_skip"`
+	TranslateAttributesScope        string            `mapstructure:"translate_attributes_scope"`
+	TranslateAttributesMappingsFile string            `mapstructure:"translate_attributes_mappings_file"`
+	TranslateAttributesWatch        bool              `mapstructure:"translate_attributes_watch"`
+	TranslateTelegrafAttributes     bool              `mapstructure:"translate_telegraf_attributes"`
+	TranslateTelegrafExtra          map[string]string `mapstructure:"translate_telegraf_extra"`
+
+	RedactAttributes     *RedactAttributesConfig     `mapstructure:"redact_attributes"`
+	RenameAttributes     *RenameAttributesConfig     `mapstructure:"rename_attributes"`
+	DropAttributes       *DropAttributesConfig       `mapstructure:"drop_attributes"`
+	NormalizeKeys        *NormalizeKeysConfig        `mapstructure:"normalize_keys"`
+	RewriteKeys          *RewriteKeysConfig          `mapstructure:"rewrite_keys"`
+	CoerceAttributes     *CoerceAttributesConfig     `mapstructure:"coerce_attributes"`
+	NormalizeBooleans    *NormalizeBooleansConfig    `mapstructure:"normalize_booleans"`
+	CopyAttributes       *CopyAttributesConfig       `mapstructure:"copy_attributes"`
+	TemplateAttributes   *TemplateAttributesConfig   `mapstructure:"template_attributes"`
+	SplitAttributes      *SplitAttributesConfig      `mapstructure:"split_attributes"`
+	TrimAttributes       *TrimAttributesConfig       `mapstructure:"trim_attributes"`
+	LimitAttributeLength *LimitAttributeLengthConfig `mapstructure:"limit_attribute_length"`
+	PrefixAttributes     *AffixAttributesConfig      `mapstructure:"prefix_attributes"`
+	SuffixAttributes     *AffixAttributesConfig      `mapstructure:"suffix_attributes"`
+	MaxCardinality       *MaxCardinalityConfig       `mapstructure:"max_cardinality"`
+	DefaultAttributes    *DefaultAttributesConfig    `mapstructure:"default_attributes"`
+	MoveAttributes       *MoveAttributesConfig       `mapstructure:"move_attributes"`
+	DedupeAttributes     bool                        `mapstructure:"dedupe_attributes"`
+
+	ParseJSONAttributes *ParseJSONAttributesConfig `mapstructure:"parse_json_attributes"`
+
+	TranslateMetricNames *TranslateMetricNamesConfig `mapstructure:"translate_metric_names"`
+	MapSeverity          *MapSeverityConfig          `mapstructure:"map_severity"`
+
+	PromoteBodyToAttributes *PromoteBodyToAttributesConfig `mapstructure:"promote_body_to_attributes"`
+	SampleByAttribute       *SampleByAttributeConfig       `mapstructure:"sample_by_attribute"`
+
+	SetTimestampFromAttribute *SetTimestampFromAttributeConfig `mapstructure:"set_timestamp_from_attribute"`
+
+	// ProcessorOrder lists sub-processor names in the order they should be run in.
+	// If empty, the default order is used.
+	ProcessorOrder []string `mapstructure:"processor_order"`
+
+	// DryRun defines whether changes should only be logged instead of applied.
+	DryRun bool `mapstructure:"dry_run"`
+
+	// IncludeExemplars defines whether attribute sub-processors should also process filtered attributes of exemplars.
+	IncludeExemplars bool `mapstructure:"include_exemplars"`
+
+	// RecordMatchDuration defines whether the time attribute sub-processors spend on a batch should be reported.
+	RecordMatchDuration bool `mapstructure:"record_match_duration"`
+
+	// Signals lists the signals which are processed, any of `logs`, `metrics` and `traces`.
+	// Other signals are passed through unchanged. If empty, all signals are processed.
+	Signals []string `mapstructure:"signals"`
+
+	// MaxKeyLength is the maximum length of an attribute key in bytes. Longer keys are not matched
+	// against patterns of sub-processors. Zero means no limit.
+	MaxKeyLength int `mapstructure:"max_key_length"`
+	// MaxKeyLengthAction is either `skip`, which only skips matching of longer keys, or `remove`,
+	// which also removes their attributes before any sub-processor runs.
+	MaxKeyLengthAction string `mapstructure:"max_key_length_action"`
+
+	// Conditions maps sub-processor names to conditions which resources and records have to satisfy to be processed.
+	Conditions map[string]ConditionConfig `mapstructure:"conditions"`
+
+	// CustomSubprocessors maps names of sub-processors registered with RegisterSubProcessor to their configuration.
+	CustomSubprocessors map[string]interface{} `mapstructure:"custom_subprocessors"`
 }
 
 const (
-	defaultAddCloudNamespace           = true
-	defaultTranslateAttributes         = true
-	defaultTranslateTelegrafAttributes = true
+	defaultAddCloudNamespace            = true
+	defaultCloudNamespaceAttribute      = defaultCloudNamespaceAttributeName
+	defaultTranslateAttributes          = true
+	defaultTranslateAttributesDirection = translateDirectionOtelToSumo
+	defaultTranslateAttributesScope     = translateScopeResource
+	defaultTranslateTelegrafAttributes  = true
+
+	defaultRedactAttributesEnabled = false
+	defaultRedactAttributesAction  = redactActionHashSha256
+
+	defaultRenameAttributesEnabled   = false
+	defaultRenameAttributesOverwrite = false
+
+	defaultDefaultAttributesEnabled = false
+	defaultDefaultAttributesScope   = defaultAttributesScopeResource
+
+	defaultDropAttributesEnabled = false
+	defaultDropAttributesMatchOn = matchOnKey
+
+	defaultNormalizeKeysEnabled   = false
+	defaultNormalizeKeysCase      = normalizeKeysCaseLower
+	defaultNormalizeKeysOverwrite = false
+
+	defaultRewriteKeysEnabled   = false
+	defaultRewriteKeysOverwrite = false
+
+	defaultCoerceAttributesEnabled = false
+	defaultCoerceAttributesType    = coerceTypeAuto
+
+	defaultCopyAttributesEnabled   = false
+	defaultCopyAttributesOverwrite = false
+
+	defaultTemplateAttributesEnabled   = false
+	defaultTemplateAttributesMissing   = templateMissingEmpty
+	defaultTemplateAttributesOverwrite = false
+
+	defaultNormalizeBooleansEnabled = false
+
+	defaultSplitAttributesEnabled           = false
+	defaultSplitAttributesPairSeparator     = ","
+	defaultSplitAttributesKeyValueSeparator = "="
+
+	defaultTrimAttributesEnabled          = false
+	defaultTrimAttributesCollapseInternal = false
+
+	defaultLimitAttributeLengthEnabled  = false
+	defaultLimitAttributeLengthMaxBytes = 4096
+	defaultLimitAttributeLengthSuffix   = ""
+
+	defaultAffixAttributesEnabled   = false
+	defaultAffixAttributesOverwrite = false
+
+	defaultMaxCardinalityEnabled     = false
+	defaultMaxCardinalityLimit       = 1000
+	defaultMaxCardinalityWindow      = time.Hour
+	defaultMaxCardinalityAction      = maxCardinalityActionDrop
+	defaultMaxCardinalityPlaceholder = "overflow"
+
+	defaultMoveAttributesEnabled         = false
+	defaultMoveAttributesDirection       = moveDirectionRecordToResource
+	defaultMoveAttributesDifferingValues = moveDifferingValuesSkip
+	defaultMoveAttributesOverwrite       = false
+
+	defaultDedupeAttributes = false
+
+	defaultParseJSONAttributesEnabled = false
+
+	defaultTranslateMetricNamesEnabled = false
+
+	defaultPromoteBodyToAttributesEnabled        = false
+	defaultPromoteBodyToAttributesPrefix         = ""
+	defaultPromoteBodyToAttributesRemoveFromBody = false
+	defaultPromoteBodyToAttributesOverwrite      = false
+
+	defaultSampleByAttributeEnabled = false
+	defaultSampleByAttributeRatio   = 1.0
+	defaultSampleByAttributeMissing = sampleMissingKeep
+
+	defaultMapSeverityEnabled   = false
+	defaultMapSeverityAttribute = "loglevel"
+	defaultMapSeverityDefault   = ""
+
+	defaultSetTimestampFromAttributeEnabled = false
+
+	defaultDryRun = false
+
+	defaultIncludeExemplars = false
+
+	defaultRecordMatchDuration = false
+
+	defaultMaxKeyLength       = 0
+	defaultMaxKeyLengthAction = maxKeyLengthActionSkip
 )
 
 // Ensure the Config struct satisfies the config.Processor interface.
@@ -35,14 +200,401 @@ var _ config.Processor = (*Config)(nil)
 
 func createDefaultConfig() config.Processor {
 	return &Config{
-		ProcessorSettings:           config.NewProcessorSettings(config.NewComponentID(typeStr)),
-		AddCloudNamespace:           defaultAddCloudNamespace,
-		TranslateAttributes:         defaultTranslateAttributes,
-		TranslateTelegrafAttributes: defaultTranslateTelegrafAttributes,
+		ProcessorSettings:            config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		AddCloudNamespace:            defaultAddCloudNamespace,
+		CloudNamespaceAttribute:      defaultCloudNamespaceAttribute,
+		CloudNamespaceMappings:       map[string]string{},
+		TranslateAttributes:          defaultTranslateAttributes,
+		TranslateAttributesExtra:     map[string]string{},
+		TranslateAttributesDirection: defaultTranslateAttributesDirection,
+		TranslateAttributesSkip:      []string{},
+		TranslateAttributesScope:     defaultTranslateAttributesScope,
+		TranslateTelegrafAttributes:  defaultTranslateTelegrafAttributes,
+		TranslateTelegrafExtra:       map[string]string{},
+		RedactAttributes: &RedactAttributesConfig{
+			Enabled:  defaultRedactAttributesEnabled,
+			Patterns: []string{},
+			Action:   defaultRedactAttributesAction,
+		},
+		RenameAttributes: &RenameAttributesConfig{
+			Enabled:   defaultRenameAttributesEnabled,
+			Mapping:   map[string]string{},
+			Overwrite: defaultRenameAttributesOverwrite,
+		},
+		DropAttributes: &DropAttributesConfig{
+			Enabled:  defaultDropAttributesEnabled,
+			Patterns: []string{},
+			MatchOn:  defaultDropAttributesMatchOn,
+		},
+		NormalizeKeys: &NormalizeKeysConfig{
+			Enabled:   defaultNormalizeKeysEnabled,
+			Case:      defaultNormalizeKeysCase,
+			Overwrite: defaultNormalizeKeysOverwrite,
+		},
+		RewriteKeys: &RewriteKeysConfig{
+			Enabled:   defaultRewriteKeysEnabled,
+			Overwrite: defaultRewriteKeysOverwrite,
+		},
+		CoerceAttributes: &CoerceAttributesConfig{
+			Enabled:  defaultCoerceAttributesEnabled,
+			Patterns: []string{},
+			Type:     defaultCoerceAttributesType,
+		},
+		NormalizeBooleans: &NormalizeBooleansConfig{
+			Enabled:     defaultNormalizeBooleansEnabled,
+			Patterns:    []string{},
+			TrueValues:  []string{},
+			FalseValues: []string{},
+		},
+		CopyAttributes: &CopyAttributesConfig{
+			Enabled:    defaultCopyAttributesEnabled,
+			Attributes: []CopyAttributePair{},
+			Overwrite:  defaultCopyAttributesOverwrite,
+		},
+		TemplateAttributes: &TemplateAttributesConfig{
+			Enabled:    defaultTemplateAttributesEnabled,
+			Attributes: []TemplateAttribute{},
+			Missing:    defaultTemplateAttributesMissing,
+			Overwrite:  defaultTemplateAttributesOverwrite,
+		},
+		SplitAttributes: &SplitAttributesConfig{
+			Enabled:           defaultSplitAttributesEnabled,
+			Attributes:        []string{},
+			PairSeparator:     defaultSplitAttributesPairSeparator,
+			KeyValueSeparator: defaultSplitAttributesKeyValueSeparator,
+		},
+		TrimAttributes: &TrimAttributesConfig{
+			Enabled:          defaultTrimAttributesEnabled,
+			Patterns:         []string{},
+			CollapseInternal: defaultTrimAttributesCollapseInternal,
+		},
+		LimitAttributeLength: &LimitAttributeLengthConfig{
+			Enabled:  defaultLimitAttributeLengthEnabled,
+			Patterns: []string{},
+			MaxBytes: defaultLimitAttributeLengthMaxBytes,
+			Suffix:   defaultLimitAttributeLengthSuffix,
+		},
+		PrefixAttributes: &AffixAttributesConfig{
+			Enabled:   defaultAffixAttributesEnabled,
+			Patterns:  []string{},
+			Overwrite: defaultAffixAttributesOverwrite,
+		},
+		SuffixAttributes: &AffixAttributesConfig{
+			Enabled:   defaultAffixAttributesEnabled,
+			Patterns:  []string{},
+			Overwrite: defaultAffixAttributesOverwrite,
+		},
+		MaxCardinality: &MaxCardinalityConfig{
+			Enabled:     defaultMaxCardinalityEnabled,
+			Keys:        []string{},
+			Limit:       defaultMaxCardinalityLimit,
+			Window:      defaultMaxCardinalityWindow,
+			Action:      defaultMaxCardinalityAction,
+			Placeholder: defaultMaxCardinalityPlaceholder,
+		},
+		DefaultAttributes: &DefaultAttributesConfig{
+			Enabled:    defaultDefaultAttributesEnabled,
+			Attributes: []DefaultAttribute{},
+			Scope:      defaultDefaultAttributesScope,
+		},
+		MoveAttributes: &MoveAttributesConfig{
+			Enabled:         defaultMoveAttributesEnabled,
+			Patterns:        []string{},
+			Direction:       defaultMoveAttributesDirection,
+			DifferingValues: defaultMoveAttributesDifferingValues,
+			Overwrite:       defaultMoveAttributesOverwrite,
+		},
+		DedupeAttributes: defaultDedupeAttributes,
+		ParseJSONAttributes: &ParseJSONAttributesConfig{
+			Enabled: defaultParseJSONAttributesEnabled,
+		},
+		TranslateMetricNames: &TranslateMetricNamesConfig{
+			Enabled: defaultTranslateMetricNamesEnabled,
+			Mapping: map[string]string{},
+			Rules:   []MetricNameRule{},
+		},
+		PromoteBodyToAttributes: &PromoteBodyToAttributesConfig{
+			Enabled:        defaultPromoteBodyToAttributesEnabled,
+			Prefix:         defaultPromoteBodyToAttributesPrefix,
+			RemoveFromBody: defaultPromoteBodyToAttributesRemoveFromBody,
+			Overwrite:      defaultPromoteBodyToAttributesOverwrite,
+		},
+		SampleByAttribute: &SampleByAttributeConfig{
+			Enabled: defaultSampleByAttributeEnabled,
+			Ratio:   defaultSampleByAttributeRatio,
+			Missing: defaultSampleByAttributeMissing,
+		},
+		MapSeverity: &MapSeverityConfig{
+			Enabled:   defaultMapSeverityEnabled,
+			Attribute: defaultMapSeverityAttribute,
+			Ranges:    []SeverityRange{},
+			Default:   defaultMapSeverityDefault,
+		},
+		SetTimestampFromAttribute: &SetTimestampFromAttributeConfig{
+			Enabled: defaultSetTimestampFromAttributeEnabled,
+			Layouts: []string{},
+		},
+		ProcessorOrder:      []string{},
+		DryRun:              defaultDryRun,
+		IncludeExemplars:    defaultIncludeExemplars,
+		MaxKeyLength:        defaultMaxKeyLength,
+		MaxKeyLengthAction:  defaultMaxKeyLengthAction,
+		Signals:             []string{},
+		Conditions:          map[string]ConditionConfig{},
+		CustomSubprocessors: map[string]interface{}{},
 	}
 }
 
 // Validate config
 func (cfg *Config) Validate() error {
+	var errs error
+
+	if cfg.AddCloudNamespace && cfg.CloudNamespaceAttribute == "" {
+		errs = multierr.Append(errs, errors.New("cloud_namespace_attribute must not be empty when add_cloud_namespace is enabled"))
+	}
+
+	if cfg.TranslateAttributes {
+		if err := validateTranslateDirection(cfg.TranslateAttributesDirection); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("translate_attributes_direction: %w", err))
+		}
+		if err := validateTranslateScope(cfg.TranslateAttributesScope); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("translate_attributes_scope: %w", err))
+		}
+		if cfg.TranslateAttributesWatch && cfg.TranslateAttributesMappingsFile == "" {
+			errs = multierr.Append(errs, errors.New("translate_attributes_watch requires translate_attributes_mappings_file"))
+		}
+	}
+
+	if cfg.RenameAttributes.Enabled {
+		if err := validateRenameAttributesConfig(cfg.RenameAttributes); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("rename_attributes: %w", err))
+		}
+	}
+
+	if cfg.RedactAttributes.Enabled {
+		if err := validateRedactAction(cfg.RedactAttributes.Action); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("redact_attributes: %w", err))
+		}
+	}
+
+	if cfg.DropAttributes.Enabled {
+		if err := validateMatchOn(cfg.DropAttributes.MatchOn); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("drop_attributes: %w", err))
+		}
+	}
+
+	if cfg.TemplateAttributes.Enabled {
+		if err := validateTemplateAttributesConfig(cfg.TemplateAttributes); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("template_attributes: %w", err))
+		}
+	}
+
+	if cfg.NormalizeKeys.Enabled {
+		if err := validateNormalizeKeysConfig(cfg.NormalizeKeys); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("normalize_keys: %w", err))
+		}
+	}
+
+	if cfg.RewriteKeys.Enabled {
+		if err := validateRewriteKeysConfig(cfg.RewriteKeys); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("rewrite_keys: %w", err))
+		}
+	}
+
+	if cfg.CoerceAttributes.Enabled {
+		if err := validateCoerceType(cfg.CoerceAttributes.Type); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("coerce_attributes: %w", err))
+		}
+	}
+
+	if cfg.NormalizeBooleans.Enabled {
+		if err := validateNormalizeBooleansConfig(cfg.NormalizeBooleans); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("normalize_booleans: %w", err))
+		}
+	}
+
+	if cfg.SplitAttributes.Enabled {
+		if err := validateSplitAttributesConfig(cfg.SplitAttributes); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("split_attributes: %w", err))
+		}
+	}
+
+	if cfg.LimitAttributeLength.Enabled {
+		if err := validateLimitAttributeLengthConfig(cfg.LimitAttributeLength); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("limit_attribute_length: %w", err))
+		}
+	}
+
+	if cfg.PrefixAttributes.Enabled {
+		if err := validateAffixAttributesConfig(cfg.PrefixAttributes); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("prefix_attributes: %w", err))
+		}
+	}
+
+	if cfg.SuffixAttributes.Enabled {
+		if err := validateAffixAttributesConfig(cfg.SuffixAttributes); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("suffix_attributes: %w", err))
+		}
+	}
+
+	if cfg.MaxCardinality.Enabled {
+		if err := validateMaxCardinalityConfig(cfg.MaxCardinality); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("max_cardinality: %w", err))
+		}
+	}
+
+	if cfg.DefaultAttributes.Enabled {
+		if err := validateDefaultAttributesConfig(cfg.DefaultAttributes); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("default_attributes: %w", err))
+		}
+	}
+
+	if cfg.MoveAttributes.Enabled {
+		if err := validateMoveAttributesConfig(cfg.MoveAttributes); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("move_attributes: %w", err))
+		}
+	}
+
+	if cfg.ParseJSONAttributes.Enabled {
+		if err := validateParseJSONAttributesConfig(cfg.ParseJSONAttributes); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("parse_json_attributes: %w", err))
+		}
+	}
+
+	if cfg.TranslateMetricNames.Enabled {
+		if err := validateTranslateMetricNamesConfig(cfg.TranslateMetricNames); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("translate_metric_names: %w", err))
+		}
+	}
+
+	if cfg.SampleByAttribute.Enabled {
+		if err := validateSampleByAttributeConfig(cfg.SampleByAttribute); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("sample_by_attribute: %w", err))
+		}
+	}
+
+	if cfg.MapSeverity.Enabled {
+		if err := validateMapSeverityConfig(cfg.MapSeverity); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("map_severity: %w", err))
+		}
+	}
+
+	if cfg.SetTimestampFromAttribute.Enabled {
+		if err := validateSetTimestampFromAttributeConfig(cfg.SetTimestampFromAttribute); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("set_timestamp_from_attribute: %w", err))
+		}
+	}
+
+	if err := validateMaxKeyLength(cfg.MaxKeyLength, cfg.MaxKeyLengthAction); err != nil {
+		errs = multierr.Append(errs, err)
+	}
+
+	if err := validateSignals(cfg.Signals); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("signals: %w", err))
+	}
+
+	seen := make(map[string]struct{}, len(cfg.ProcessorOrder))
+	for _, name := range cfg.ProcessorOrder {
+		if _, duplicate := seen[name]; duplicate {
+			errs = multierr.Append(errs, fmt.Errorf("processor_order: duplicate sub-processor %q", name))
+		}
+		seen[name] = struct{}{}
+	}
+
+	names := make([]string, 0, len(cfg.Conditions))
+	for name := range cfg.Conditions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := validateConditionConfig(cfg.Conditions[name]); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("conditions: %s: %w", name, err))
+		}
+	}
+
+	errs = multierr.Append(errs, cfg.validateDroppedTargets())
+
+	return errs
+}
+
+// validateDroppedTargets checks that no attribute created by rename_attributes, copy_attributes
+// or template_attributes is removed by drop_attributes afterwards.
+func (cfg *Config) validateDroppedTargets() error {
+	if !cfg.DropAttributes.Enabled || cfg.DropAttributes.MatchOn != matchOnKey {
+		return nil
+	}
+
+	regexes, err := compileWildcards(cfg.DropAttributes.Patterns, cfg.DropAttributes.WildcardOptions)
+	if err != nil {
+		return fmt.Errorf("drop_attributes: %w", err)
+	}
+
+	var errs error
+
+	if cfg.RenameAttributes.Enabled && cfg.runsBeforeDropAttributes("rename_attributes") {
+		oldNames := make([]string, 0, len(cfg.RenameAttributes.Mapping))
+		for oldName := range cfg.RenameAttributes.Mapping {
+			oldNames = append(oldNames, oldName)
+		}
+		sort.Strings(oldNames)
+		for _, oldName := range oldNames {
+			newName := cfg.RenameAttributes.Mapping[oldName]
+			if matchesAnyRegex(regexes, newName) {
+				errs = multierr.Append(errs, fmt.Errorf("rename_attributes: new name %q is dropped by drop_attributes", newName))
+			}
+		}
+	}
+
+	if cfg.CopyAttributes.Enabled && cfg.runsBeforeDropAttributes("copy_attributes") {
+		for _, pair := range cfg.CopyAttributes.Attributes {
+			if matchesAnyRegex(regexes, pair.To) {
+				errs = multierr.Append(errs, fmt.Errorf("copy_attributes: target name %q is dropped by drop_attributes", pair.To))
+			}
+		}
+	}
+
+	if cfg.TemplateAttributes.Enabled && cfg.runsBeforeDropAttributes("template_attributes") {
+		for _, attribute := range cfg.TemplateAttributes.Attributes {
+			if matchesAnyRegex(regexes, attribute.Key) {
+				errs = multierr.Append(errs, fmt.Errorf("template_attributes: target name %q is dropped by drop_attributes", attribute.Key))
+			}
+		}
+	}
+
+	return errs
+}
+
+// runsBeforeDropAttributes returns true if the sub-processor with the given name runs before drop_attributes.
+func (cfg *Config) runsBeforeDropAttributes(name string) bool {
+	if len(cfg.ProcessorOrder) == 0 {
+		// In the default order, rename_attributes, copy_attributes and template_attributes run before drop_attributes.
+		return true
+	}
+
+	for _, orderedName := range cfg.ProcessorOrder {
+		switch orderedName {
+		case name:
+			return true
+		case "drop_attributes":
+			return false
+		}
+	}
+	return false
+}
+
+func validateSignals(signals []string) error {
+	seen := make(map[string]struct{}, len(signals))
+	for _, signal := range signals {
+		switch signal {
+		case signalLogs, signalMetrics, signalTraces:
+		default:
+			return fmt.Errorf("invalid signal: %q", signal)
+		}
+		if _, duplicate := seen[signal]; duplicate {
+			return fmt.Errorf("duplicate signal: %q", signal)
+		}
+		seen[signal] = struct{}{}
+	}
+
 	return nil
 }
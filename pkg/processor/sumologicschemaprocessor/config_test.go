@@ -40,41 +40,387 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, p0, factory.CreateDefaultConfig())
 
 	p1 := cfg.Processors[config.NewComponentIDWithName(typeStr, "disabled-cloud-namespace")]
-
-	assert.Equal(t, p1,
-		&Config{
-			ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(
-				typeStr,
-				"disabled-cloud-namespace",
-			)),
-			AddCloudNamespace:           false,
-			TranslateAttributes:         true,
-			TranslateTelegrafAttributes: true,
-		})
+	expected1 := newConfigWithName("disabled-cloud-namespace")
+	expected1.AddCloudNamespace = false
+	assert.Equal(t, p1, expected1)
 
 	p2 := cfg.Processors[config.NewComponentIDWithName(typeStr, "disabled-attribute-translation")]
-
-	assert.Equal(t, p2,
-		&Config{
-			ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(
-				typeStr,
-				"disabled-attribute-translation",
-			)),
-			AddCloudNamespace:           true,
-			TranslateAttributes:         false,
-			TranslateTelegrafAttributes: true,
-		})
+	expected2 := newConfigWithName("disabled-attribute-translation")
+	expected2.TranslateAttributes = false
+	assert.Equal(t, p2, expected2)
 
 	p3 := cfg.Processors[config.NewComponentIDWithName(typeStr, "disabled-telegraf-attribute-translation")]
+	expected3 := newConfigWithName("disabled-telegraf-attribute-translation")
+	expected3.TranslateTelegrafAttributes = false
+	assert.Equal(t, p3, expected3)
+
+	p4 := cfg.Processors[config.NewComponentIDWithName(typeStr, "redact-attributes")]
+	expected4 := newConfigWithName("redact-attributes")
+	expected4.RedactAttributes = &RedactAttributesConfig{
+		Enabled:  true,
+		Patterns: []string{"user.email", "*.token"},
+		Action:   "mask",
+	}
+	assert.Equal(t, p4, expected4)
+
+	p5 := cfg.Processors[config.NewComponentIDWithName(typeStr, "rename-attributes")]
+	expected5 := newConfigWithName("rename-attributes")
+	expected5.RenameAttributes = &RenameAttributesConfig{
+		Enabled: true,
+		Mapping: map[string]string{
+			"host":      "host.name",
+			"namespace": "k8s.namespace.name",
+		},
+		Overwrite:       true,
+		Rules:           []AttributeNameRule{{Pattern: "pod_*", Replacement: "k8s.pod.*"}},
+		WildcardOptions: WildcardOptions{CaseInsensitive: true},
+	}
+	assert.Equal(t, p5, expected5)
+
+	p6 := cfg.Processors[config.NewComponentIDWithName(typeStr, "copy-attributes")]
+	expected6 := newConfigWithName("copy-attributes")
+	expected6.CopyAttributes = &CopyAttributesConfig{
+		Enabled: true,
+		Attributes: []CopyAttributePair{
+			{From: "host.name", To: "host"},
+		},
+		Overwrite: false,
+	}
+	assert.Equal(t, p6, expected6)
+
+	p7 := cfg.Processors[config.NewComponentIDWithName(typeStr, "cloud-namespace-mappings")]
+	expected7 := newConfigWithName("cloud-namespace-mappings")
+	expected7.CloudNamespaceMappings = map[string]string{
+		"aws_ec2":          "custom/ec2",
+		"private_platform": "private",
+	}
+	assert.Equal(t, p7, expected7)
 
-	assert.Equal(t, p3,
-		&Config{
-			ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(
-				typeStr,
-				"disabled-telegraf-attribute-translation",
-			)),
-			AddCloudNamespace:           true,
-			TranslateAttributes:         true,
-			TranslateTelegrafAttributes: false,
+	p8 := cfg.Processors[config.NewComponentIDWithName(typeStr, "normalize-booleans")]
+	expected8 := newConfigWithName("normalize-booleans")
+	expected8.NormalizeBooleans = &NormalizeBooleansConfig{
+		Enabled:     true,
+		Patterns:    []string{"*.enabled"},
+		TrueValues:  []string{"ja"},
+		FalseValues: []string{},
+	}
+	assert.Equal(t, p8, expected8)
+
+	p9 := cfg.Processors[config.NewComponentIDWithName(typeStr, "logs-only")]
+	expected9 := newConfigWithName("logs-only")
+	expected9.Signals = []string{"logs"}
+	assert.Equal(t, p9, expected9)
+}
+
+func TestValidateConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		modify      func(*Config)
+		expectedErr string
+	}{
+		{
+			name:   "default config is valid",
+			modify: func(*Config) {},
+		},
+		{
+			name: "empty cloud_namespace_attribute",
+			modify: func(cfg *Config) {
+				cfg.CloudNamespaceAttribute = ""
+			},
+			expectedErr: "cloud_namespace_attribute must not be empty when add_cloud_namespace is enabled",
+		},
+		{
+			name: "empty cloud_namespace_attribute with add_cloud_namespace disabled",
+			modify: func(cfg *Config) {
+				cfg.AddCloudNamespace = false
+				cfg.CloudNamespaceAttribute = ""
+			},
+		},
+		{
+			name: "invalid translate_attributes_direction",
+			modify: func(cfg *Config) {
+				cfg.TranslateAttributesDirection = "both"
+			},
+			expectedErr: `translate_attributes_direction: invalid translation direction: "both"`,
+		},
+		{
+			name: "invalid translate_attributes_scope",
+			modify: func(cfg *Config) {
+				cfg.TranslateAttributesScope = "span"
+			},
+			expectedErr: `translate_attributes_scope: invalid translation scope: "span"`,
+		},
+		{
+			name: "empty translate_metric_names replacement",
+			modify: func(cfg *Config) {
+				cfg.TranslateMetricNames.Enabled = true
+				cfg.TranslateMetricNames.Rules = []MetricNameRule{{Pattern: "system.*"}}
+			},
+			expectedErr: "translate_metric_names: rule 0: replacement must not be empty",
+		},
+		{
+			name: "invalid map_severity range",
+			modify: func(cfg *Config) {
+				cfg.MapSeverity.Enabled = true
+				cfg.MapSeverity.Ranges = []SeverityRange{{Min: 0, Max: 4, Label: "TRACE"}}
+			},
+			expectedErr: "map_severity: range 0: invalid severity range 0-4",
+		},
+		{
+			name: "empty set_timestamp_from_attribute attribute",
+			modify: func(cfg *Config) {
+				cfg.SetTimestampFromAttribute.Enabled = true
+			},
+			expectedErr: "set_timestamp_from_attribute: attribute must not be empty",
+		},
+		{
+			name: "invalid limit_attribute_length max_bytes",
+			modify: func(cfg *Config) {
+				cfg.LimitAttributeLength.Enabled = true
+				cfg.LimitAttributeLength.MaxBytes = 0
+			},
+			expectedErr: "limit_attribute_length: max_bytes must be greater than 0",
+		},
+		{
+			name: "empty prefix_attributes affix",
+			modify: func(cfg *Config) {
+				cfg.PrefixAttributes.Enabled = true
+			},
+			expectedErr: "prefix_attributes: affix must not be empty",
+		},
+		{
+			name: "invalid signal",
+			modify: func(cfg *Config) {
+				cfg.Signals = []string{"logs", "profiles"}
+			},
+			expectedErr: `signals: invalid signal: "profiles"`,
+		},
+		{
+			name: "duplicate signal",
+			modify: func(cfg *Config) {
+				cfg.Signals = []string{"logs", "logs"}
+			},
+			expectedErr: `signals: duplicate signal: "logs"`,
+		},
+		{
+			name: "negative max_key_length",
+			modify: func(cfg *Config) {
+				cfg.MaxKeyLength = -1
+			},
+			expectedErr: "max_key_length must not be negative, got -1",
+		},
+		{
+			name: "duplicate processor_order entry",
+			modify: func(cfg *Config) {
+				cfg.ProcessorOrder = []string{"translate_attributes", "translate_attributes"}
+			},
+			expectedErr: `processor_order: duplicate sub-processor "translate_attributes"`,
+		},
+		{
+			name: "invalid condition",
+			modify: func(cfg *Config) {
+				cfg.Conditions = map[string]ConditionConfig{
+					"redact_attributes": {Value: "k8s"},
+				}
+			},
+			expectedErr: "conditions: redact_attributes: attribute must not be empty",
+		},
+		{
+			name: "invalid redact action",
+			modify: func(cfg *Config) {
+				cfg.RedactAttributes.Enabled = true
+				cfg.RedactAttributes.Action = "encrypt"
+			},
+			expectedErr: `redact_attributes: invalid redact action: "encrypt"`,
+		},
+		{
+			name: "invalid normalize_keys case",
+			modify: func(cfg *Config) {
+				cfg.NormalizeKeys.Enabled = true
+				cfg.NormalizeKeys.Case = "title"
+			},
+			expectedErr: `normalize_keys: invalid case: "title"`,
+		},
+		{
+			name: "invalid move_attributes differing_values",
+			modify: func(cfg *Config) {
+				cfg.MoveAttributes.Enabled = true
+				cfg.MoveAttributes.DifferingValues = "merge"
+			},
+			expectedErr: `move_attributes: invalid differing_values strategy: "merge"`,
+		},
+		{
+			name: "invalid rewrite_keys pattern",
+			modify: func(cfg *Config) {
+				cfg.RewriteKeys.Enabled = true
+			},
+			expectedErr: `rewrite_keys: pattern must not be empty`,
+		},
+		{
+			name: "invalid coerce_attributes type",
+			modify: func(cfg *Config) {
+				cfg.CoerceAttributes.Enabled = true
+				cfg.CoerceAttributes.Type = "string"
+			},
+			expectedErr: `coerce_attributes: invalid coerce type: "string"`,
+		},
+		{
+			name: "empty split_attributes separator",
+			modify: func(cfg *Config) {
+				cfg.SplitAttributes.Enabled = true
+				cfg.SplitAttributes.PairSeparator = ""
+			},
+			expectedErr: "split_attributes: pair_separator must not be empty",
+		},
+		{
+			name: "empty parse_json_attributes attribute",
+			modify: func(cfg *Config) {
+				cfg.ParseJSONAttributes.Enabled = true
+			},
+			expectedErr: "parse_json_attributes: attribute must not be empty",
+		},
+		{
+			name: "translate_attributes_watch without mappings file",
+			modify: func(cfg *Config) {
+				cfg.TranslateAttributesWatch = true
+			},
+			expectedErr: "translate_attributes_watch requires translate_attributes_mappings_file",
+		},
+		{
+			name: "rename_attributes watch without mappings file",
+			modify: func(cfg *Config) {
+				cfg.RenameAttributes.Enabled = true
+				cfg.RenameAttributes.Watch = true
+			},
+			expectedErr: "rename_attributes: watch requires mappings_file",
+		},
+		{
+			name: "rename_attributes rule without replacement",
+			modify: func(cfg *Config) {
+				cfg.RenameAttributes.Enabled = true
+				cfg.RenameAttributes.Rules = []AttributeNameRule{{Pattern: "pod_*"}}
+			},
+			expectedErr: "rename_attributes: rule 0: replacement must not be empty",
+		},
+		{
+			name: "invalid default_attributes type",
+			modify: func(cfg *Config) {
+				cfg.DefaultAttributes.Enabled = true
+				cfg.DefaultAttributes.Attributes = []DefaultAttribute{{Key: "sampled", Value: "yes", Type: attributeTypeBool}}
+			},
+			expectedErr: `default_attributes: attribute "sampled": value is not a bool`,
+		},
+		{
+			name: "invalid move_attributes direction",
+			modify: func(cfg *Config) {
+				cfg.MoveAttributes.Enabled = true
+				cfg.MoveAttributes.Direction = "up"
+			},
+			expectedErr: `move_attributes: invalid direction: "up"`,
+		},
+		{
+			name: "invalid drop_attributes match_on",
+			modify: func(cfg *Config) {
+				cfg.DropAttributes.Enabled = true
+				cfg.DropAttributes.MatchOn = "both"
+			},
+			expectedErr: `drop_attributes: invalid match_on: "both"`,
+		},
+		{
+			name: "renamed attribute matches drop_attributes patterns for values",
+			modify: func(cfg *Config) {
+				cfg.RenameAttributes.Enabled = true
+				cfg.RenameAttributes.Mapping = map[string]string{"pod": "k8s.pod.name"}
+				cfg.DropAttributes.Enabled = true
+				cfg.DropAttributes.Patterns = []string{"k8s.*"}
+				cfg.DropAttributes.MatchOn = matchOnValue
+			},
+		},
+		{
+			name: "renamed attribute is dropped",
+			modify: func(cfg *Config) {
+				cfg.RenameAttributes.Enabled = true
+				cfg.RenameAttributes.Mapping = map[string]string{"pod": "k8s.pod.name"}
+				cfg.DropAttributes.Enabled = true
+				cfg.DropAttributes.Patterns = []string{"k8s.*"}
+			},
+			expectedErr: `rename_attributes: new name "k8s.pod.name" is dropped by drop_attributes`,
+		},
+		{
+			name: "copied attribute is dropped",
+			modify: func(cfg *Config) {
+				cfg.CopyAttributes.Enabled = true
+				cfg.CopyAttributes.Attributes = []CopyAttributePair{{From: "host", To: "host.name"}}
+				cfg.DropAttributes.Enabled = true
+				cfg.DropAttributes.Patterns = []string{"host.name"}
+			},
+			expectedErr: `copy_attributes: target name "host.name" is dropped by drop_attributes`,
+		},
+		{
+			name: "templated attribute is dropped",
+			modify: func(cfg *Config) {
+				cfg.TemplateAttributes.Enabled = true
+				cfg.TemplateAttributes.Attributes = []TemplateAttribute{{Key: "endpoint", Template: "{host}:{port}"}}
+				cfg.DropAttributes.Enabled = true
+				cfg.DropAttributes.Patterns = []string{"end*"}
+			},
+			expectedErr: `template_attributes: target name "endpoint" is dropped by drop_attributes`,
+		},
+		{
+			name: "invalid template_attributes template",
+			modify: func(cfg *Config) {
+				cfg.TemplateAttributes.Enabled = true
+				cfg.TemplateAttributes.Attributes = []TemplateAttribute{{Key: "endpoint", Template: "{host"}}
+			},
+			expectedErr: `template_attributes: attribute "endpoint": unterminated reference at offset 0`,
+		},
+		{
+			name: "renamed attribute matches drop_attributes which runs first",
+			modify: func(cfg *Config) {
+				cfg.AddCloudNamespace = false
+				cfg.TranslateAttributes = false
+				cfg.TranslateTelegrafAttributes = false
+				cfg.RenameAttributes.Enabled = true
+				cfg.RenameAttributes.Mapping = map[string]string{"pod": "k8s.pod.name"}
+				cfg.DropAttributes.Enabled = true
+				cfg.DropAttributes.Patterns = []string{"k8s.*"}
+				cfg.ProcessorOrder = []string{"drop_attributes", "rename_attributes"}
+			},
+		},
+		{
+			name: "all problems are reported",
+			modify: func(cfg *Config) {
+				cfg.CloudNamespaceAttribute = ""
+				cfg.TranslateAttributesScope = "span"
+				cfg.Conditions = map[string]ConditionConfig{
+					"trim_attributes":   {},
+					"redact_attributes": {Value: "k8s"},
+				}
+			},
+			expectedErr: "cloud_namespace_attribute must not be empty when add_cloud_namespace is enabled; " +
+				`translate_attributes_scope: invalid translation scope: "span"; ` +
+				"conditions: redact_attributes: attribute must not be empty; " +
+				"conditions: trim_attributes: attribute must not be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			testCase.modify(cfg)
+
+			err := cfg.Validate()
+			if testCase.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testCase.expectedErr)
+			}
 		})
+	}
+}
+
+func newConfigWithName(name string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ProcessorSettings = config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, name))
+	return cfg
 }
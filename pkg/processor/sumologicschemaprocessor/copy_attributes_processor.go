@@ -0,0 +1,103 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// CopyAttributesConfig configures the copy_attributes sub-processor.
+type CopyAttributesConfig struct {
+	Enabled    bool                `mapstructure:"enabled"`
+	Attributes []CopyAttributePair `mapstructure:"attributes"`
+	// Overwrite defines whether an attribute which already has the target name should be overwritten.
+	Overwrite bool `mapstructure:"overwrite"`
+}
+
+// CopyAttributePair defines the source and the target attribute name of a copy.
+type CopyAttributePair struct {
+	From string `mapstructure:"from"`
+	To   string `mapstructure:"to"`
+}
+
+// copyAttributesProcessor duplicates attributes under new names.
+type copyAttributesProcessor struct {
+	enabled    bool
+	overwrite  bool
+	attributes []CopyAttributePair
+}
+
+func newCopyAttributesProcessor(config *CopyAttributesConfig) (*copyAttributesProcessor, error) {
+	return &copyAttributesProcessor{
+		enabled:    config.Enabled,
+		overwrite:  config.Overwrite,
+		attributes: config.Attributes,
+	}, nil
+}
+
+func (proc *copyAttributesProcessor) ProcessLogs(logs plog.Logs) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnLogs(proc, logs)
+	}
+	return nil
+}
+
+func (proc *copyAttributesProcessor) ProcessMetrics(metrics pmetric.Metrics) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnMetrics(proc, metrics)
+	}
+	return nil
+}
+
+func (proc *copyAttributesProcessor) ProcessTraces(traces ptrace.Traces) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnTraces(proc, traces)
+	}
+	return nil
+}
+
+func (proc *copyAttributesProcessor) IsEnabled() bool {
+	return proc.enabled
+}
+
+func (*copyAttributesProcessor) ConfigPropertyName() string {
+	return "copy_attributes"
+}
+
+func (proc *copyAttributesProcessor) processAttributes(attributes pcommon.Map) int {
+	for _, pair := range proc.attributes {
+		if pair.From == pair.To {
+			continue
+		}
+
+		source, found := attributes.Get(pair.From)
+		if !found {
+			continue
+		}
+
+		if target, exists := attributes.Get(pair.To); exists {
+			if proc.overwrite {
+				source.CopyTo(target)
+			}
+			continue
+		}
+
+		attributes.Insert(pair.To, source)
+	}
+	return 0
+}
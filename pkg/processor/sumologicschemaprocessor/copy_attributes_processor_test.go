@@ -0,0 +1,126 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestCopyAttributes(t *testing.T) {
+	testCases := []struct {
+		name       string
+		attributes []CopyAttributePair
+		overwrite  bool
+		input      map[string]interface{}
+		expected   map[string]interface{}
+	}{
+		{
+			name:       "copies attribute",
+			attributes: []CopyAttributePair{{From: "host.name", To: "host"}},
+			input: map[string]interface{}{
+				"host.name": "my-host",
+			},
+			expected: map[string]interface{}{
+				"host.name": "my-host",
+				"host":      "my-host",
+			},
+		},
+		{
+			name:       "copies map values",
+			attributes: []CopyAttributePair{{From: "labels", To: "labels_copy"}},
+			input: map[string]interface{}{
+				"labels": map[string]interface{}{"app": "nginx"},
+			},
+			expected: map[string]interface{}{
+				"labels":      map[string]interface{}{"app": "nginx"},
+				"labels_copy": map[string]interface{}{"app": "nginx"},
+			},
+		},
+		{
+			name:       "does nothing when source does not exist",
+			attributes: []CopyAttributePair{{From: "host.name", To: "host"}},
+			input: map[string]interface{}{
+				"other": "value",
+			},
+			expected: map[string]interface{}{
+				"other": "value",
+			},
+		},
+		{
+			name:       "does not overwrite existing target",
+			attributes: []CopyAttributePair{{From: "host.name", To: "host"}},
+			input: map[string]interface{}{
+				"host.name": "new",
+				"host":      "old",
+			},
+			expected: map[string]interface{}{
+				"host.name": "new",
+				"host":      "old",
+			},
+		},
+		{
+			name:       "overwrites existing target when configured",
+			attributes: []CopyAttributePair{{From: "host.name", To: "host"}},
+			overwrite:  true,
+			input: map[string]interface{}{
+				"host.name": int64(1),
+				"host":      "old",
+			},
+			expected: map[string]interface{}{
+				"host.name": int64(1),
+				"host":      int64(1),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			processor, err := newCopyAttributesProcessor(&CopyAttributesConfig{
+				Enabled:    true,
+				Attributes: testCase.attributes,
+				Overwrite:  testCase.overwrite,
+			})
+			require.NoError(t, err)
+
+			attributes := pcommon.NewMapFromRaw(testCase.input)
+			processor.processAttributes(attributes)
+
+			assert.Equal(t, testCase.expected, attributes.AsRaw())
+		})
+	}
+}
+
+func TestCopyAttributesCopyIsIndependent(t *testing.T) {
+	processor, err := newCopyAttributesProcessor(&CopyAttributesConfig{
+		Enabled:    true,
+		Attributes: []CopyAttributePair{{From: "labels", To: "labels_copy"}},
+	})
+	require.NoError(t, err)
+
+	attributes := pcommon.NewMapFromRaw(map[string]interface{}{
+		"labels": map[string]interface{}{"app": "nginx"},
+	})
+	processor.processAttributes(attributes)
+
+	labels, _ := attributes.Get("labels")
+	labels.MapVal().UpsertString("app", "changed")
+
+	labelsCopy, _ := attributes.Get("labels_copy")
+	assert.Equal(t, map[string]interface{}{"app": "nginx"}, labelsCopy.MapVal().AsRaw())
+}
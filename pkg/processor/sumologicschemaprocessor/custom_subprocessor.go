@@ -0,0 +1,88 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// SubProcessorFactory creates a SubProcessor from its raw configuration,
+// i.e. the value under its name in `custom_subprocessors`, or nil if it is not configured.
+type SubProcessorFactory func(cfg interface{}) (SubProcessor, error)
+
+var (
+	customSubprocessorFactoriesLock sync.RWMutex
+	customSubprocessorFactories     = map[string]SubProcessorFactory{}
+)
+
+// RegisterSubProcessor registers a factory of a custom sub-processor under the given name.
+// Custom sub-processors are run after the built-in ones, unless `processor_order` says otherwise.
+// It is meant to be called from an init function, before any processor is created.
+// It returns an error if the name is empty, the factory is nil or the name is already registered.
+func RegisterSubProcessor(name string, factory SubProcessorFactory) error {
+	if name == "" {
+		return errors.New("sub-processor name must not be empty")
+	}
+	if factory == nil {
+		return fmt.Errorf("sub-processor %q: factory must not be nil", name)
+	}
+
+	customSubprocessorFactoriesLock.Lock()
+	defer customSubprocessorFactoriesLock.Unlock()
+
+	if _, duplicate := customSubprocessorFactories[name]; duplicate {
+		return fmt.Errorf("sub-processor %q is already registered", name)
+	}
+	customSubprocessorFactories[name] = factory
+	return nil
+}
+
+// newCustomSubprocessors creates all registered custom sub-processors, sorted by name.
+func newCustomSubprocessors(configs map[string]interface{}) ([]SubProcessor, error) {
+	customSubprocessorFactoriesLock.RLock()
+	defer customSubprocessorFactoriesLock.RUnlock()
+
+	for name := range configs {
+		if _, ok := customSubprocessorFactories[name]; !ok {
+			return nil, fmt.Errorf("custom_subprocessors: unknown sub-processor %q", name)
+		}
+	}
+
+	names := make([]string, 0, len(customSubprocessorFactories))
+	for name := range customSubprocessorFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	subprocessors := make([]SubProcessor, 0, len(names))
+	for _, name := range names {
+		subprocessor, err := customSubprocessorFactories[name](configs[name])
+		if err != nil {
+			return nil, fmt.Errorf("custom_subprocessors: %s: %w", name, err)
+		}
+		if subprocessor == nil {
+			return nil, fmt.Errorf("custom_subprocessors: %s: factory returned no sub-processor", name)
+		}
+		if subprocessor.ConfigPropertyName() != name {
+			return nil, fmt.Errorf("custom_subprocessors: %s: sub-processor reports a different name %q", name, subprocessor.ConfigPropertyName())
+		}
+		subprocessors = append(subprocessors, subprocessor)
+	}
+
+	return subprocessors, nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// setOwnerSubprocessor sets the `owner` resource attribute to the configured value.
+type setOwnerSubprocessor struct {
+	owner   string
+	started bool
+}
+
+func (proc *setOwnerSubprocessor) ProcessLogs(logs plog.Logs) error {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		logs.ResourceLogs().At(i).Resource().Attributes().UpsertString("owner", proc.owner)
+	}
+	return nil
+}
+
+func (*setOwnerSubprocessor) ProcessMetrics(pmetric.Metrics) error { return nil }
+func (*setOwnerSubprocessor) ProcessTraces(ptrace.Traces) error    { return nil }
+func (proc *setOwnerSubprocessor) IsEnabled() bool                 { return proc.owner != "" }
+func (*setOwnerSubprocessor) ConfigPropertyName() string           { return "set_owner" }
+
+func (proc *setOwnerSubprocessor) Start(context.Context, component.Host) error {
+	proc.started = true
+	return nil
+}
+
+func (*setOwnerSubprocessor) Shutdown(context.Context) error { return nil }
+
+// registerTestSubProcessor registers a sub-processor and unregisters it when the test finishes.
+func registerTestSubProcessor(t *testing.T, name string, factory SubProcessorFactory) {
+	require.NoError(t, RegisterSubProcessor(name, factory))
+	t.Cleanup(func() {
+		customSubprocessorFactoriesLock.Lock()
+		defer customSubprocessorFactoriesLock.Unlock()
+		delete(customSubprocessorFactories, name)
+	})
+}
+
+func TestCustomSubprocessor(t *testing.T) {
+	var created *setOwnerSubprocessor
+	registerTestSubProcessor(t, "set_owner", func(cfg interface{}) (SubProcessor, error) {
+		created = &setOwnerSubprocessor{}
+		if cfg == nil {
+			return created, nil
+		}
+		values, ok := cfg.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("invalid configuration")
+		}
+		created.owner, _ = values["owner"].(string)
+		return created, nil
+	})
+
+	config := createDefaultConfig().(*Config)
+	config.TranslateAttributes = false
+	config.CustomSubprocessors = map[string]interface{}{
+		"set_owner": map[string]interface{}{"owner": "platform"},
+	}
+
+	sink := new(consumertest.LogsSink)
+	processor, err := NewFactory().CreateLogsProcessor(context.Background(), newProcessorCreateSettings(), config, sink)
+	require.NoError(t, err)
+	require.NoError(t, processor.Start(context.Background(), componenttest.NewNopHost()))
+	assert.True(t, created.started)
+
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().Resource().Attributes().InsertString("team", "a")
+	require.NoError(t, processor.ConsumeLogs(context.Background(), logs))
+	require.NoError(t, processor.Shutdown(context.Background()))
+
+	require.Len(t, sink.AllLogs(), 1)
+	assert.Equal(t,
+		map[string]interface{}{"team": "a", "owner": "platform"},
+		sink.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().AsRaw(),
+	)
+}
+
+func TestCustomSubprocessorErrors(t *testing.T) {
+	registerTestSubProcessor(t, "failing", func(cfg interface{}) (SubProcessor, error) {
+		return nil, errors.New("invalid configuration")
+	})
+
+	config := createDefaultConfig().(*Config)
+	_, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), config)
+	assert.EqualError(t, err, "custom_subprocessors: failing: invalid configuration")
+
+	config.CustomSubprocessors = map[string]interface{}{"unknown": nil}
+	_, err = newSumologicSchemaProcessor(newProcessorCreateSettings(), config)
+	assert.EqualError(t, err, `custom_subprocessors: unknown sub-processor "unknown"`)
+}
+
+func TestCustomSubprocessorNameMismatch(t *testing.T) {
+	registerTestSubProcessor(t, "owner", func(cfg interface{}) (SubProcessor, error) {
+		return &setOwnerSubprocessor{}, nil
+	})
+
+	_, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), createDefaultConfig().(*Config))
+	assert.EqualError(t, err, `custom_subprocessors: owner: sub-processor reports a different name "set_owner"`)
+}
+
+// renamedSubprocessor reports its name instead of the name of the wrapped sub-processor.
+type renamedSubprocessor struct {
+	SubProcessor
+	name string
+}
+
+func (proc *renamedSubprocessor) ConfigPropertyName() string { return proc.name }
+
+func TestCustomSubprocessorBuiltInName(t *testing.T) {
+	registerTestSubProcessor(t, "drop_attributes", func(cfg interface{}) (SubProcessor, error) {
+		return &renamedSubprocessor{SubProcessor: &setOwnerSubprocessor{}, name: "drop_attributes"}, nil
+	})
+
+	_, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), createDefaultConfig().(*Config))
+	assert.EqualError(t, err, `custom_subprocessors: "drop_attributes" is the name of a built-in sub-processor`)
+}
+
+func TestRegisterSubProcessorDuplicate(t *testing.T) {
+	factory := func(cfg interface{}) (SubProcessor, error) { return &setOwnerSubprocessor{}, nil }
+	registerTestSubProcessor(t, "duplicate", factory)
+
+	assert.EqualError(t, RegisterSubProcessor("duplicate", factory), `sub-processor "duplicate" is already registered`)
+	assert.EqualError(t, RegisterSubProcessor("", factory), "sub-processor name must not be empty")
+	assert.EqualError(t, RegisterSubProcessor("other", nil), `sub-processor "other": factory must not be nil`)
+}
+
+// failingSubprocessor fails to process any data with its err.
+type failingSubprocessor struct {
+	err error
+}
+
+func (proc *failingSubprocessor) ProcessLogs(plog.Logs) error          { return proc.err }
+func (proc *failingSubprocessor) ProcessMetrics(pmetric.Metrics) error { return proc.err }
+func (proc *failingSubprocessor) ProcessTraces(ptrace.Traces) error    { return proc.err }
+func (*failingSubprocessor) IsEnabled() bool                           { return true }
+func (*failingSubprocessor) ConfigPropertyName() string                { return "failing" }
+
+func TestSubprocessorErrorContext(t *testing.T) {
+	errFailed := errors.New("inconsistent attribute count")
+	registerTestSubProcessor(t, "failing", func(cfg interface{}) (SubProcessor, error) {
+		return &failingSubprocessor{err: errFailed}, nil
+	})
+
+	for _, dryRun := range []bool{false, true} {
+		config := createDefaultConfig().(*Config)
+		config.DryRun = dryRun
+		processor, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), config)
+		require.NoError(t, err)
+
+		_, err = processor.processLogs(context.Background(), plog.NewLogs())
+		assert.EqualError(t, err, "failed to process logs for property failing: inconsistent attribute count")
+		assert.ErrorIs(t, err, errFailed)
+
+		_, err = processor.processMetrics(context.Background(), pmetric.NewMetrics())
+		assert.EqualError(t, err, "failed to process metrics for property failing: inconsistent attribute count")
+		assert.ErrorIs(t, err, errFailed)
+
+		_, err = processor.processTraces(context.Background(), ptrace.NewTraces())
+		assert.EqualError(t, err, "failed to process traces for property failing: inconsistent attribute count")
+		assert.ErrorIs(t, err, errFailed)
+	}
+}
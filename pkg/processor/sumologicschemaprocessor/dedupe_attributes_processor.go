@@ -0,0 +1,89 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// dedupeAttributesProcessor removes record attributes which are already present with the same value
+// in resource attributes.
+type dedupeAttributesProcessor struct {
+	shouldDedupe bool
+}
+
+func newDedupeAttributesProcessor(shouldDedupe bool) (*dedupeAttributesProcessor, error) {
+	return &dedupeAttributesProcessor{
+		shouldDedupe: shouldDedupe,
+	}, nil
+}
+
+func (proc *dedupeAttributesProcessor) ProcessLogs(logs plog.Logs) error {
+	if proc.shouldDedupe {
+		removed := 0
+		processLogRecordsAttributes(logs, func(resourceAttributes pcommon.Map, attributes pcommon.Map) {
+			removed += dedupeAttributes(resourceAttributes, attributes)
+		})
+		recordAttributesRemoved(proc.ConfigPropertyName(), signalLogs, removed)
+	}
+	return nil
+}
+
+func (proc *dedupeAttributesProcessor) ProcessMetrics(metrics pmetric.Metrics) error {
+	if proc.shouldDedupe {
+		removed := 0
+		processDataPointsAttributesWithResource(metrics, func(resourceAttributes pcommon.Map, attributes pcommon.Map) {
+			removed += dedupeAttributes(resourceAttributes, attributes)
+		})
+		recordAttributesRemoved(proc.ConfigPropertyName(), signalMetrics, removed)
+	}
+	return nil
+}
+
+func (proc *dedupeAttributesProcessor) ProcessTraces(traces ptrace.Traces) error {
+	if proc.shouldDedupe {
+		removed := 0
+		processSpansAttributes(traces, func(resourceAttributes pcommon.Map, attributes pcommon.Map) {
+			removed += dedupeAttributes(resourceAttributes, attributes)
+		})
+		recordAttributesRemoved(proc.ConfigPropertyName(), signalTraces, removed)
+	}
+	return nil
+}
+
+func (proc *dedupeAttributesProcessor) IsEnabled() bool {
+	return proc.shouldDedupe
+}
+
+func (*dedupeAttributesProcessor) ConfigPropertyName() string {
+	return "dedupe_attributes"
+}
+
+// dedupeAttributes returns the number of removed attributes.
+func dedupeAttributes(resourceAttributes pcommon.Map, attributes pcommon.Map) int {
+	removed := 0
+	attributes.RemoveIf(func(key string, value pcommon.Value) bool {
+		resourceValue, found := resourceAttributes.Get(key)
+		if found && resourceValue.Equal(value) {
+			removed++
+			return true
+		}
+		return false
+	})
+	return removed
+}
@@ -0,0 +1,83 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestDedupeAttributes(t *testing.T) {
+	resourceAttributes := pcommon.NewMapFromRaw(map[string]interface{}{
+		"host":      "my-host",
+		"namespace": "default",
+		"port":      int64(80),
+	})
+	attributes := pcommon.NewMapFromRaw(map[string]interface{}{
+		"host":      "my-host",
+		"namespace": "kube-system",
+		"port":      "80",
+		"pod":       "my-pod",
+	})
+
+	dedupeAttributes(resourceAttributes, attributes)
+
+	assert.Equal(t, map[string]interface{}{
+		"namespace": "kube-system",
+		"port":      "80",
+		"pod":       "my-pod",
+	}, attributes.AsRaw())
+	assert.Equal(t, 3, resourceAttributes.Len())
+}
+
+func TestDedupeAttributesAllSignals(t *testing.T) {
+	processor, err := newDedupeAttributesProcessor(true)
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	resourceLogs.Resource().Attributes().InsertString("host", "my-host")
+	logRecord := resourceLogs.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	logRecord.Attributes().InsertString("host", "my-host")
+	require.NoError(t, processor.ProcessLogs(logs))
+	assert.Equal(t, 0, logRecord.Attributes().Len())
+	assert.Equal(t, 1, resourceLogs.Resource().Attributes().Len())
+
+	metrics := pmetric.NewMetrics()
+	resourceMetrics := metrics.ResourceMetrics().AppendEmpty()
+	resourceMetrics.Resource().Attributes().InsertString("host", "my-host")
+	metric := resourceMetrics.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetDataType(pmetric.MetricDataTypeGauge)
+	dataPoint := metric.Gauge().DataPoints().AppendEmpty()
+	dataPoint.Attributes().InsertString("host", "my-host")
+	require.NoError(t, processor.ProcessMetrics(metrics))
+	assert.Equal(t, 0, dataPoint.Attributes().Len())
+	assert.Equal(t, 1, resourceMetrics.Resource().Attributes().Len())
+
+	traces := ptrace.NewTraces()
+	resourceSpans := traces.ResourceSpans().AppendEmpty()
+	resourceSpans.Resource().Attributes().InsertString("host", "my-host")
+	span := resourceSpans.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.Attributes().InsertString("host", "my-host")
+	require.NoError(t, processor.ProcessTraces(traces))
+	assert.Equal(t, 0, span.Attributes().Len())
+	assert.Equal(t, 1, resourceSpans.Resource().Attributes().Len())
+}
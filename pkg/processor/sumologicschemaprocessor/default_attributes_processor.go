@@ -0,0 +1,227 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	attributeTypeString = "string"
+	attributeTypeInt    = "int"
+	attributeTypeDouble = "double"
+	attributeTypeBool   = "bool"
+
+	defaultAttributesScopeAll      = "all"
+	defaultAttributesScopeResource = "resource"
+	defaultAttributesScopeRecord   = "record"
+)
+
+// DefaultAttributesConfig configures the default_attributes sub-processor.
+type DefaultAttributesConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Attributes are the attributes to set when they are missing.
+	Attributes []DefaultAttribute `mapstructure:"attributes"`
+	// Scope is one of `resource`, `record` or `all`.
+	Scope string `mapstructure:"scope"`
+}
+
+// DefaultAttribute is an attribute with its default value.
+type DefaultAttribute struct {
+	Key   string `mapstructure:"key"`
+	Value string `mapstructure:"value"`
+	// Type is one of `string`, `int`, `double` or `bool`. If empty, `string` is used.
+	Type string `mapstructure:"type"`
+}
+
+// defaultAttributesProcessor sets attributes which are missing to their default values.
+type defaultAttributesProcessor struct {
+	enabled         bool
+	keys            []string
+	values          []pcommon.Value
+	processResource bool
+	processRecord   bool
+}
+
+func newDefaultAttributesProcessor(config *DefaultAttributesConfig) (*defaultAttributesProcessor, error) {
+	proc := &defaultAttributesProcessor{
+		enabled:         config.Enabled,
+		processResource: config.Scope == defaultAttributesScopeAll || config.Scope == defaultAttributesScopeResource,
+		processRecord:   config.Scope == defaultAttributesScopeAll || config.Scope == defaultAttributesScopeRecord,
+	}
+	if !config.Enabled {
+		return proc, nil
+	}
+
+	if err := validateDefaultAttributesConfig(config); err != nil {
+		return nil, err
+	}
+
+	for _, attribute := range config.Attributes {
+		// Validation has already checked that the value can be parsed.
+		value, _ := parseDefaultAttributeValue(attribute)
+		proc.keys = append(proc.keys, attribute.Key)
+		proc.values = append(proc.values, value)
+	}
+
+	return proc, nil
+}
+
+func validateDefaultAttributesConfig(config *DefaultAttributesConfig) error {
+	switch config.Scope {
+	case defaultAttributesScopeAll, defaultAttributesScopeResource, defaultAttributesScopeRecord:
+	default:
+		return fmt.Errorf("invalid scope: %q", config.Scope)
+	}
+
+	for i, attribute := range config.Attributes {
+		if attribute.Key == "" {
+			return fmt.Errorf("attribute %d: key must not be empty", i)
+		}
+		if _, err := parseDefaultAttributeValue(attribute); err != nil {
+			return fmt.Errorf("attribute %q: %w", attribute.Key, err)
+		}
+	}
+
+	return nil
+}
+
+func parseDefaultAttributeValue(attribute DefaultAttribute) (pcommon.Value, error) {
+	switch attribute.Type {
+	case "", attributeTypeString:
+		return pcommon.NewValueString(attribute.Value), nil
+	case attributeTypeInt:
+		intVal, err := strconv.ParseInt(attribute.Value, 10, 64)
+		if err != nil {
+			return pcommon.Value{}, errors.New("value is not an int")
+		}
+		return pcommon.NewValueInt(intVal), nil
+	case attributeTypeDouble:
+		doubleVal, err := strconv.ParseFloat(attribute.Value, 64)
+		if err != nil {
+			return pcommon.Value{}, errors.New("value is not a double")
+		}
+		return pcommon.NewValueDouble(doubleVal), nil
+	case attributeTypeBool:
+		boolVal, err := strconv.ParseBool(attribute.Value)
+		if err != nil {
+			return pcommon.Value{}, errors.New("value is not a bool")
+		}
+		return pcommon.NewValueBool(boolVal), nil
+	default:
+		return pcommon.Value{}, fmt.Errorf("invalid type: %q", attribute.Type)
+	}
+}
+
+func (proc *defaultAttributesProcessor) ProcessLogs(logs plog.Logs) error {
+	if !proc.enabled {
+		return nil
+	}
+
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		resourceLogs := logs.ResourceLogs().At(i)
+		if proc.processResource {
+			proc.setDefaults(resourceLogs.Resource().Attributes())
+		}
+		if !proc.processRecord {
+			continue
+		}
+
+		for j := 0; j < resourceLogs.ScopeLogs().Len(); j++ {
+			logRecords := resourceLogs.ScopeLogs().At(j).LogRecords()
+
+			for k := 0; k < logRecords.Len(); k++ {
+				proc.setDefaults(logRecords.At(k).Attributes())
+			}
+		}
+	}
+
+	return nil
+}
+
+func (proc *defaultAttributesProcessor) ProcessMetrics(metrics pmetric.Metrics) error {
+	if !proc.enabled {
+		return nil
+	}
+
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		resourceMetrics := metrics.ResourceMetrics().At(i)
+		if proc.processResource {
+			proc.setDefaults(resourceMetrics.Resource().Attributes())
+		}
+		if !proc.processRecord {
+			continue
+		}
+
+		for j := 0; j < resourceMetrics.ScopeMetrics().Len(); j++ {
+			metricsSlice := resourceMetrics.ScopeMetrics().At(j).Metrics()
+
+			for k := 0; k < metricsSlice.Len(); k++ {
+				processDataPointsAttributes(metricsSlice.At(k), proc.setDefaults)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (proc *defaultAttributesProcessor) ProcessTraces(traces ptrace.Traces) error {
+	if !proc.enabled {
+		return nil
+	}
+
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		resourceSpans := traces.ResourceSpans().At(i)
+		if proc.processResource {
+			proc.setDefaults(resourceSpans.Resource().Attributes())
+		}
+		if !proc.processRecord {
+			continue
+		}
+
+		for j := 0; j < resourceSpans.ScopeSpans().Len(); j++ {
+			spans := resourceSpans.ScopeSpans().At(j).Spans()
+
+			for k := 0; k < spans.Len(); k++ {
+				proc.setDefaults(spans.At(k).Attributes())
+			}
+		}
+	}
+
+	return nil
+}
+
+func (proc *defaultAttributesProcessor) IsEnabled() bool {
+	return proc.enabled
+}
+
+func (*defaultAttributesProcessor) ConfigPropertyName() string {
+	return "default_attributes"
+}
+
+// setDefaults inserts the default attributes which are missing from the attributes.
+// It is not called processAttributes, because, depending on the scope, it must not be run on all attribute maps.
+func (proc *defaultAttributesProcessor) setDefaults(attributes pcommon.Map) {
+	for i, key := range proc.keys {
+		attributes.Insert(key, proc.values[i])
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestDefaultAttributes(t *testing.T) {
+	processor, err := newDefaultAttributesProcessor(&DefaultAttributesConfig{
+		Enabled: true,
+		Attributes: []DefaultAttribute{
+			{Key: "service.name", Value: "unknown"},
+			{Key: "port", Value: "8080", Type: attributeTypeInt},
+			{Key: "ratio", Value: "0.5", Type: attributeTypeDouble},
+			{Key: "sampled", Value: "true", Type: attributeTypeBool},
+		},
+		Scope: defaultAttributesScopeResource,
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		input    map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name:  "sets missing attributes",
+			input: map[string]interface{}{},
+			expected: map[string]interface{}{
+				"service.name": "unknown",
+				"port":         int64(8080),
+				"ratio":        0.5,
+				"sampled":      true,
+			},
+		},
+		{
+			name: "does not overwrite present attributes",
+			input: map[string]interface{}{
+				"service.name": "my-service",
+				"port":         "http",
+			},
+			expected: map[string]interface{}{
+				"service.name": "my-service",
+				"port":         "http",
+				"ratio":        0.5,
+				"sampled":      true,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			attributes := pcommon.NewMapFromRaw(testCase.input)
+			processor.setDefaults(attributes)
+			assert.Equal(t, testCase.expected, attributes.AsRaw())
+		})
+	}
+}
+
+func TestDefaultAttributesScope(t *testing.T) {
+	testCases := []struct {
+		scope            string
+		expectedResource map[string]interface{}
+		expectedRecord   map[string]interface{}
+	}{
+		{
+			scope:            defaultAttributesScopeResource,
+			expectedResource: map[string]interface{}{"service.name": "unknown"},
+			expectedRecord:   map[string]interface{}{},
+		},
+		{
+			scope:            defaultAttributesScopeRecord,
+			expectedResource: map[string]interface{}{},
+			expectedRecord:   map[string]interface{}{"service.name": "unknown"},
+		},
+		{
+			scope:            defaultAttributesScopeAll,
+			expectedResource: map[string]interface{}{"service.name": "unknown"},
+			expectedRecord:   map[string]interface{}{"service.name": "unknown"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.scope, func(t *testing.T) {
+			processor, err := newDefaultAttributesProcessor(&DefaultAttributesConfig{
+				Enabled:    true,
+				Attributes: []DefaultAttribute{{Key: "service.name", Value: "unknown"}},
+				Scope:      testCase.scope,
+			})
+			require.NoError(t, err)
+
+			logs := plog.NewLogs()
+			resourceLogs := logs.ResourceLogs().AppendEmpty()
+			logRecord := resourceLogs.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+			require.NoError(t, processor.ProcessLogs(logs))
+			assert.Equal(t, testCase.expectedResource, resourceLogs.Resource().Attributes().AsRaw())
+			assert.Equal(t, testCase.expectedRecord, logRecord.Attributes().AsRaw())
+
+			metrics := pmetric.NewMetrics()
+			resourceMetrics := metrics.ResourceMetrics().AppendEmpty()
+			metric := resourceMetrics.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+			metric.SetDataType(pmetric.MetricDataTypeGauge)
+			dataPoint := metric.Gauge().DataPoints().AppendEmpty()
+			require.NoError(t, processor.ProcessMetrics(metrics))
+			assert.Equal(t, testCase.expectedResource, resourceMetrics.Resource().Attributes().AsRaw())
+			assert.Equal(t, testCase.expectedRecord, dataPoint.Attributes().AsRaw())
+
+			traces := ptrace.NewTraces()
+			resourceSpans := traces.ResourceSpans().AppendEmpty()
+			span := resourceSpans.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			require.NoError(t, processor.ProcessTraces(traces))
+			assert.Equal(t, testCase.expectedResource, resourceSpans.Resource().Attributes().AsRaw())
+			assert.Equal(t, testCase.expectedRecord, span.Attributes().AsRaw())
+		})
+	}
+}
+
+func TestDefaultAttributesInvalidConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      DefaultAttributesConfig
+		expectedErr string
+	}{
+		{
+			name:        "invalid scope",
+			config:      DefaultAttributesConfig{Scope: "span"},
+			expectedErr: `invalid scope: "span"`,
+		},
+		{
+			name: "empty key",
+			config: DefaultAttributesConfig{
+				Attributes: []DefaultAttribute{{Value: "unknown"}},
+				Scope:      defaultAttributesScopeResource,
+			},
+			expectedErr: "attribute 0: key must not be empty",
+		},
+		{
+			name: "invalid type",
+			config: DefaultAttributesConfig{
+				Attributes: []DefaultAttribute{{Key: "port", Value: "8080", Type: "uint"}},
+				Scope:      defaultAttributesScopeResource,
+			},
+			expectedErr: `attribute "port": invalid type: "uint"`,
+		},
+		{
+			name: "value not matching type",
+			config: DefaultAttributesConfig{
+				Attributes: []DefaultAttribute{{Key: "port", Value: "http", Type: attributeTypeInt}},
+				Scope:      defaultAttributesScopeResource,
+			},
+			expectedErr: `attribute "port": value is not an int`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.config.Enabled = true
+			_, err := newDefaultAttributesProcessor(&testCase.config)
+			assert.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
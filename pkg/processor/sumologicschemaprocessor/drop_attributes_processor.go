@@ -0,0 +1,130 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	matchOnKey   = "key"
+	matchOnValue = "value"
+)
+
+// DropAttributesConfig configures the drop_attributes sub-processor.
+type DropAttributesConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Patterns are attribute keys to drop. The `*` character matches any sequence of characters.
+	Patterns []string `mapstructure:"patterns"`
+	// WildcardOptions change how Patterns are matched.
+	WildcardOptions `mapstructure:",squash"`
+	// MatchOn defines whether patterns are matched against attribute keys or against attribute values.
+	// Values of other types than string are converted to strings before matching.
+	MatchOn string `mapstructure:"match_on"`
+}
+
+// dropAttributesProcessor removes attributes with keys or values matching configured wildcard patterns.
+type dropAttributesProcessor struct {
+	enabled      bool
+	regexes      []*regexp.Regexp
+	maxKeyLength int
+	matchOnValue bool
+}
+
+func newDropAttributesProcessor(config *DropAttributesConfig) (*dropAttributesProcessor, error) {
+	if config.Enabled {
+		if err := validateMatchOn(config.MatchOn); err != nil {
+			return nil, err
+		}
+	}
+
+	regexes, err := compileWildcards(config.Patterns, config.WildcardOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dropAttributesProcessor{
+		enabled:      config.Enabled,
+		regexes:      regexes,
+		matchOnValue: config.MatchOn == matchOnValue,
+	}, nil
+}
+
+func validateMatchOn(matchOn string) error {
+	switch matchOn {
+	case matchOnKey, matchOnValue:
+		return nil
+	default:
+		return fmt.Errorf("invalid match_on: %q", matchOn)
+	}
+}
+
+func (proc *dropAttributesProcessor) ProcessLogs(logs plog.Logs) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnLogs(proc, logs)
+	}
+	return nil
+}
+
+func (proc *dropAttributesProcessor) ProcessMetrics(metrics pmetric.Metrics) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnMetrics(proc, metrics)
+	}
+	return nil
+}
+
+func (proc *dropAttributesProcessor) ProcessTraces(traces ptrace.Traces) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnTraces(proc, traces)
+	}
+	return nil
+}
+
+func (proc *dropAttributesProcessor) IsEnabled() bool {
+	return proc.enabled
+}
+
+func (*dropAttributesProcessor) ConfigPropertyName() string {
+	return "drop_attributes"
+}
+
+func (proc *dropAttributesProcessor) setMaxKeyLength(maxKeyLength int) {
+	proc.maxKeyLength = maxKeyLength
+}
+
+func (proc *dropAttributesProcessor) processAttributes(attributes pcommon.Map) int {
+	removed := 0
+	attributes.RemoveIf(func(key string, value pcommon.Value) bool {
+		var matches bool
+		if proc.matchOnValue {
+			// Values are subject to max_key_length as well, as they can be much longer than keys.
+			str := value.AsString()
+			matches = !exceedsMaxKeyLength(str, proc.maxKeyLength) && matchesAnyRegex(proc.regexes, str)
+		} else {
+			matches = matchesAnyKeyRegex(proc.regexes, key, proc.maxKeyLength)
+		}
+		if matches {
+			removed++
+		}
+		return matches
+	})
+	return removed
+}
@@ -0,0 +1,156 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestDropAttributes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		patterns []string
+		input    map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name:     "drops attributes matching exact key",
+			patterns: []string{"pod_uid"},
+			input: map[string]interface{}{
+				"pod_uid":  "abc",
+				"pod_name": "pod",
+			},
+			expected: map[string]interface{}{
+				"pod_name": "pod",
+			},
+		},
+		{
+			name:     "drops attributes matching wildcards",
+			patterns: []string{"pod_*_label", "*.internal"},
+			input: map[string]interface{}{
+				"pod_app_label":  "app",
+				"pod_tier_label": "tier",
+				"pod_label":      "label",
+				"k8s.internal":   "x",
+				"k8s.internals":  "y",
+			},
+			expected: map[string]interface{}{
+				"pod_label":     "label",
+				"k8s.internals": "y",
+			},
+		},
+		{
+			name:     "treats regex special characters literally",
+			patterns: []string{"a.b", "(c)"},
+			input: map[string]interface{}{
+				"a.b":  "1",
+				"aXb":  "2",
+				"(c)":  "3",
+				"c":    "4",
+				"a.bc": "5",
+			},
+			expected: map[string]interface{}{
+				"aXb":  "2",
+				"c":    "4",
+				"a.bc": "5",
+			},
+		},
+		{
+			name:     "drops nothing without patterns",
+			patterns: []string{},
+			input: map[string]interface{}{
+				"key": "value",
+			},
+			expected: map[string]interface{}{
+				"key": "value",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			processor, err := newDropAttributesProcessor(&DropAttributesConfig{
+				Enabled:  true,
+				Patterns: testCase.patterns,
+				MatchOn:  matchOnKey,
+			})
+			require.NoError(t, err)
+
+			attributes := pcommon.NewMapFromRaw(testCase.input)
+			processor.processAttributes(attributes)
+
+			assert.Equal(t, testCase.expected, attributes.AsRaw())
+		})
+	}
+}
+
+func TestDropAttributesMatchOnValue(t *testing.T) {
+	processor, err := newDropAttributesProcessor(&DropAttributesConfig{
+		Enabled:  true,
+		Patterns: []string{"unknown", "1*"},
+		MatchOn:  matchOnValue,
+	})
+	require.NoError(t, err)
+
+	attributes := pcommon.NewMapFromRaw(map[string]interface{}{
+		"pod_name":  "unknown",
+		"node_name": "my-node",
+		"unknown":   "value",
+		"port":      1234,
+		"ratio":     0.5,
+		"enabled":   true,
+	})
+	processor.processAttributes(attributes)
+
+	assert.Equal(t, map[string]interface{}{
+		"node_name": "my-node",
+		"unknown":   "value",
+		"ratio":     0.5,
+		"enabled":   true,
+	}, attributes.AsRaw())
+}
+
+func TestDropAttributesMatchOnValueMaxKeyLength(t *testing.T) {
+	processor, err := newDropAttributesProcessor(&DropAttributesConfig{
+		Enabled:  true,
+		Patterns: []string{"unknown*"},
+		MatchOn:  matchOnValue,
+	})
+	require.NoError(t, err)
+	processor.setMaxKeyLength(10)
+
+	attributes := pcommon.NewMapFromRaw(map[string]interface{}{
+		"short": "unknown",
+		"long":  "unknown-but-too-long",
+	})
+	removed := processor.processAttributes(attributes)
+
+	assert.Equal(t, 1, removed)
+	assert.Equal(t, map[string]interface{}{
+		"long": "unknown-but-too-long",
+	}, attributes.AsRaw())
+}
+
+func TestDropAttributesInvalidMatchOn(t *testing.T) {
+	_, err := newDropAttributesProcessor(&DropAttributesConfig{
+		Enabled: true,
+		MatchOn: "both",
+	})
+	assert.EqualError(t, err, `invalid match_on: "both"`)
+}
@@ -0,0 +1,209 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// changes describes what a sub-processor changed in a batch of data.
+type changes struct {
+	addedKeys      map[string]struct{}
+	removedKeys    map[string]struct{}
+	changedKeys    map[string]struct{}
+	renamedMetrics map[string]struct{}
+	// droppedRecords is the number of records which were removed.
+	droppedRecords int
+}
+
+func newChanges() *changes {
+	return &changes{
+		addedKeys:      map[string]struct{}{},
+		removedKeys:    map[string]struct{}{},
+		changedKeys:    map[string]struct{}{},
+		renamedMetrics: map[string]struct{}{},
+	}
+}
+
+func (c *changes) isEmpty() bool {
+	return len(c.addedKeys) == 0 && len(c.removedKeys) == 0 && len(c.changedKeys) == 0 && len(c.renamedMetrics) == 0 &&
+		c.droppedRecords == 0
+}
+
+// compareAttributes records the differences between attribute maps.
+// Both slices have to hold the maps of the same resources and records in the same order.
+func (c *changes) compareAttributes(before []pcommon.Map, after []pcommon.Map) {
+	for i := 0; i < len(before) && i < len(after); i++ {
+		before[i].Range(func(key string, beforeValue pcommon.Value) bool {
+			afterValue, found := after[i].Get(key)
+			if !found {
+				c.removedKeys[key] = struct{}{}
+			} else if !beforeValue.Equal(afterValue) {
+				c.changedKeys[key] = struct{}{}
+			}
+			return true
+		})
+		after[i].Range(func(key string, _ pcommon.Value) bool {
+			if _, found := before[i].Get(key); !found {
+				c.addedKeys[key] = struct{}{}
+			}
+			return true
+		})
+	}
+}
+
+func (c *changes) fields(subprocessor SubProcessor) []zap.Field {
+	fields := []zap.Field{
+		zap.String("sub_processor", subprocessor.ConfigPropertyName()),
+		zap.Strings("added_keys", sortedKeys(c.addedKeys)),
+		zap.Strings("removed_keys", sortedKeys(c.removedKeys)),
+		zap.Strings("changed_keys", sortedKeys(c.changedKeys)),
+	}
+	if len(c.renamedMetrics) > 0 {
+		fields = append(fields, zap.Strings("renamed_metrics", sortedKeys(c.renamedMetrics)))
+	}
+	if c.droppedRecords > 0 {
+		fields = append(fields, zap.Int("dropped_records", c.droppedRecords))
+	}
+	return fields
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// dryRunLogs runs the sub-processors on a copy of the logs and logs the changes each of them would make.
+func (processor *sumologicSchemaProcessor) dryRunLogs(logs plog.Logs) error {
+	working := logs.Clone()
+
+	for _, subprocessor := range processor.enabledSubprocessors {
+		before := working.Clone()
+
+		if err := subprocessor.ProcessLogs(working); err != nil {
+			return fmt.Errorf("failed to process logs for property %s: %w", subprocessor.ConfigPropertyName(), err)
+		}
+
+		c := newChanges()
+		if dropped := before.LogRecordCount() - working.LogRecordCount(); dropped > 0 {
+			// Attribute maps are compared by position, which is not possible after records were removed.
+			c.droppedRecords = dropped
+		} else {
+			c.compareAttributes(collectLogsAttributes(before), collectLogsAttributes(working))
+		}
+		processor.logDryRunChanges(subprocessor, c)
+	}
+
+	return nil
+}
+
+// dryRunMetrics runs the sub-processors on a copy of the metrics and logs the changes each of them would make.
+func (processor *sumologicSchemaProcessor) dryRunMetrics(metrics pmetric.Metrics) error {
+	working := metrics.Clone()
+
+	for _, subprocessor := range processor.enabledSubprocessors {
+		before := working.Clone()
+
+		if err := subprocessor.ProcessMetrics(working); err != nil {
+			return fmt.Errorf("failed to process metrics for property %s: %w", subprocessor.ConfigPropertyName(), err)
+		}
+
+		c := newChanges()
+		c.compareAttributes(collectMetricsAttributes(before), collectMetricsAttributes(working))
+		beforeNames, afterNames := collectMetricNames(before), collectMetricNames(working)
+		for i := 0; i < len(beforeNames) && i < len(afterNames); i++ {
+			if beforeNames[i] != afterNames[i] {
+				c.renamedMetrics[beforeNames[i]+" => "+afterNames[i]] = struct{}{}
+			}
+		}
+		processor.logDryRunChanges(subprocessor, c)
+	}
+
+	return nil
+}
+
+// dryRunTraces runs the sub-processors on a copy of the traces and logs the changes each of them would make.
+func (processor *sumologicSchemaProcessor) dryRunTraces(traces ptrace.Traces) error {
+	working := traces.Clone()
+
+	for _, subprocessor := range processor.enabledSubprocessors {
+		before := working.Clone()
+
+		if err := subprocessor.ProcessTraces(working); err != nil {
+			return fmt.Errorf("failed to process traces for property %s: %w", subprocessor.ConfigPropertyName(), err)
+		}
+
+		c := newChanges()
+		c.compareAttributes(collectTracesAttributes(before), collectTracesAttributes(working))
+		processor.logDryRunChanges(subprocessor, c)
+	}
+
+	return nil
+}
+
+func (processor *sumologicSchemaProcessor) logDryRunChanges(subprocessor SubProcessor, c *changes) {
+	if !c.isEmpty() {
+		processor.logger.Info("Dry run: sub-processor would change data", c.fields(subprocessor)...)
+	}
+}
+
+func collectLogsAttributes(logs plog.Logs) []pcommon.Map {
+	maps := []pcommon.Map{}
+	processLogsAttributes(logs, func(attributes pcommon.Map) {
+		maps = append(maps, attributes)
+	})
+	return maps
+}
+
+func collectMetricsAttributes(metrics pmetric.Metrics) []pcommon.Map {
+	maps := []pcommon.Map{}
+	processMetricsAttributes(metrics, func(attributes pcommon.Map) {
+		maps = append(maps, attributes)
+	})
+	return maps
+}
+
+func collectTracesAttributes(traces ptrace.Traces) []pcommon.Map {
+	maps := []pcommon.Map{}
+	processTracesAttributes(traces, func(attributes pcommon.Map) {
+		maps = append(maps, attributes)
+	})
+	return maps
+}
+
+func collectMetricNames(metrics pmetric.Metrics) []string {
+	names := []string{}
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		scopeMetrics := metrics.ResourceMetrics().At(i).ScopeMetrics()
+		for j := 0; j < scopeMetrics.Len(); j++ {
+			metricsSlice := scopeMetrics.At(j).Metrics()
+			for k := 0; k < metricsSlice.Len(); k++ {
+				names = append(names, metricsSlice.At(k).Name())
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,141 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newDryRunProcessor(t *testing.T, modify func(*Config)) (*sumologicSchemaProcessor, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	settings := component.ProcessorCreateSettings{
+		TelemetrySettings: component.TelemetrySettings{
+			Logger: zap.New(core),
+		},
+	}
+
+	config := createDefaultConfig().(*Config)
+	config.DryRun = true
+	modify(config)
+
+	processor, err := newSumologicSchemaProcessor(settings, config)
+	require.NoError(t, err)
+	return processor, logs
+}
+
+func TestDryRunLogs(t *testing.T) {
+	processor, observedLogs := newDryRunProcessor(t, func(config *Config) {
+		config.DropAttributes.Enabled = true
+		config.DropAttributes.Patterns = []string{"secret"}
+	})
+
+	logs := plog.NewLogs()
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	resourceLogs.Resource().Attributes().InsertString("host.name", "testing-host")
+	resourceLogs.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().InsertString("secret", "a")
+	expected := logs.Clone()
+
+	result, err := processor.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+	assert.Equal(t, expected, result)
+
+	entries := observedLogs.FilterMessage("Dry run: sub-processor would change data").All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "translate_attributes", entries[0].ContextMap()["sub_processor"])
+	assert.Equal(t, []interface{}{"host"}, entries[0].ContextMap()["added_keys"])
+	assert.Equal(t, []interface{}{"host.name"}, entries[0].ContextMap()["removed_keys"])
+	assert.Equal(t, "drop_attributes", entries[1].ContextMap()["sub_processor"])
+	assert.Equal(t, []interface{}{"secret"}, entries[1].ContextMap()["removed_keys"])
+}
+
+func TestDryRunLogsDroppedRecords(t *testing.T) {
+	processor, observedLogs := newDryRunProcessor(t, func(config *Config) {
+		config.TranslateAttributes = false
+		config.SampleByAttribute.Enabled = true
+		config.SampleByAttribute.Key = "trace_id"
+		config.SampleByAttribute.Ratio = 0
+	})
+
+	logs := plog.NewLogs()
+	logRecords := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	logRecords.AppendEmpty().Attributes().InsertString("trace_id", "a")
+	logRecords.AppendEmpty().Attributes().InsertString("trace_id", "b")
+	logRecords.AppendEmpty()
+	expected := logs.Clone()
+
+	result, err := processor.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+	assert.Equal(t, expected, result)
+
+	entries := observedLogs.FilterMessage("Dry run: sub-processor would change data").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "sample_by_attribute", entries[0].ContextMap()["sub_processor"])
+	assert.Equal(t, int64(2), entries[0].ContextMap()["dropped_records"])
+}
+
+func TestDryRunMetrics(t *testing.T) {
+	processor, observedLogs := newDryRunProcessor(t, func(config *Config) {})
+
+	metrics := pmetric.NewMetrics()
+	resourceMetrics := metrics.ResourceMetrics().AppendEmpty()
+	resourceMetrics.Resource().Attributes().InsertString("cloud.provider", "aws")
+	resourceMetrics.Resource().Attributes().InsertString("cloud.platform", "aws_ec2")
+	resourceMetrics.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("cpu_usage_irq")
+	expected := metrics.Clone()
+
+	result, err := processor.processMetrics(context.Background(), metrics)
+	require.NoError(t, err)
+	assert.Equal(t, expected, result)
+
+	entries := observedLogs.FilterMessage("Dry run: sub-processor would change data").All()
+	require.Len(t, entries, 3)
+	assert.Equal(t, "add_cloud_namespace", entries[0].ContextMap()["sub_processor"])
+	assert.Equal(t, []interface{}{"cloud.namespace"}, entries[0].ContextMap()["added_keys"])
+	assert.Equal(t, "translate_attributes", entries[1].ContextMap()["sub_processor"])
+	assert.Equal(t, "translate_telegraf_attributes", entries[2].ContextMap()["sub_processor"])
+	assert.Equal(t, []interface{}{"cpu_usage_irq => CPU_Irq"}, entries[2].ContextMap()["renamed_metrics"])
+}
+
+func TestDryRunTraces(t *testing.T) {
+	processor, observedLogs := newDryRunProcessor(t, func(config *Config) {
+		config.RedactAttributes.Enabled = true
+		config.RedactAttributes.Patterns = []string{"user.email"}
+	})
+
+	traces := ptrace.NewTraces()
+	resourceSpans := traces.ResourceSpans().AppendEmpty()
+	resourceSpans.ScopeSpans().AppendEmpty().Spans().AppendEmpty().Attributes().InsertString("user.email", "john@example.com")
+	expected := traces.Clone()
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	assert.Equal(t, expected, result)
+
+	entries := observedLogs.FilterMessage("Dry run: sub-processor would change data").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "redact_attributes", entries[0].ContextMap()["sub_processor"])
+	assert.Equal(t, []interface{}{"user.email"}, entries[0].ContextMap()["changed_keys"])
+}
@@ -0,0 +1,137 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"errors"
+	"regexp"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// LimitAttributeLengthConfig configures the limit_attribute_length sub-processor.
+type LimitAttributeLengthConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Patterns are attribute keys to limit. The `*` character matches any sequence of characters.
+	Patterns []string `mapstructure:"patterns"`
+	// WildcardOptions change how Patterns are matched.
+	WildcardOptions `mapstructure:",squash"`
+	// MaxBytes is the maximum length of a value in bytes, including Suffix.
+	MaxBytes int `mapstructure:"max_bytes"`
+	// Suffix is appended to truncated values.
+	Suffix string `mapstructure:"suffix"`
+}
+
+// limitAttributeLengthProcessor truncates string attribute values longer than the limit.
+type limitAttributeLengthProcessor struct {
+	enabled      bool
+	maxBytes     int
+	suffix       string
+	regexes      []*regexp.Regexp
+	maxKeyLength int
+}
+
+func newLimitAttributeLengthProcessor(config *LimitAttributeLengthConfig) (*limitAttributeLengthProcessor, error) {
+	if config.Enabled {
+		if err := validateLimitAttributeLengthConfig(config); err != nil {
+			return nil, err
+		}
+	}
+
+	regexes, err := compileWildcards(config.Patterns, config.WildcardOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &limitAttributeLengthProcessor{
+		enabled:  config.Enabled,
+		maxBytes: config.MaxBytes,
+		suffix:   config.Suffix,
+		regexes:  regexes,
+	}, nil
+}
+
+func validateLimitAttributeLengthConfig(config *LimitAttributeLengthConfig) error {
+	if config.MaxBytes <= 0 {
+		return errors.New("max_bytes must be greater than 0")
+	}
+	if len(config.Suffix) >= config.MaxBytes {
+		return errors.New("suffix must be shorter than max_bytes")
+	}
+	return nil
+}
+
+func (proc *limitAttributeLengthProcessor) ProcessLogs(logs plog.Logs) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnLogs(proc, logs)
+	}
+	return nil
+}
+
+func (proc *limitAttributeLengthProcessor) ProcessMetrics(metrics pmetric.Metrics) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnMetrics(proc, metrics)
+	}
+	return nil
+}
+
+func (proc *limitAttributeLengthProcessor) ProcessTraces(traces ptrace.Traces) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnTraces(proc, traces)
+	}
+	return nil
+}
+
+func (proc *limitAttributeLengthProcessor) IsEnabled() bool {
+	return proc.enabled
+}
+
+func (*limitAttributeLengthProcessor) ConfigPropertyName() string {
+	return "limit_attribute_length"
+}
+
+func (proc *limitAttributeLengthProcessor) setMaxKeyLength(maxKeyLength int) {
+	proc.maxKeyLength = maxKeyLength
+}
+
+func (proc *limitAttributeLengthProcessor) processAttributes(attributes pcommon.Map) int {
+	attributes.Range(func(key string, value pcommon.Value) bool {
+		if value.Type() != pcommon.ValueTypeString || len(value.StringVal()) <= proc.maxBytes || !matchesAnyKeyRegex(proc.regexes, key, proc.maxKeyLength) {
+			return true
+		}
+
+		value.SetStringVal(truncateOnRuneBoundary(value.StringVal(), proc.maxBytes-len(proc.suffix)) + proc.suffix)
+		return true
+	})
+	return 0
+}
+
+// truncateOnRuneBoundary returns the longest prefix of s which has at most maxBytes bytes
+// and does not end in the middle of a multi-byte character.
+func truncateOnRuneBoundary(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut]
+}
@@ -0,0 +1,125 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestLimitAttributeLength(t *testing.T) {
+	testCases := []struct {
+		name     string
+		maxBytes int
+		suffix   string
+		input    map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name:     "truncates long values",
+			maxBytes: 5,
+			input: map[string]interface{}{
+				"exception.stacktrace": "0123456789",
+				"exception.message":    "short",
+				"other":                "0123456789",
+			},
+			expected: map[string]interface{}{
+				"exception.stacktrace": "01234",
+				"exception.message":    "short",
+				"other":                "0123456789",
+			},
+		},
+		{
+			name:     "appends suffix within the limit",
+			maxBytes: 8,
+			suffix:   "...",
+			input: map[string]interface{}{
+				"exception.stacktrace": "0123456789",
+			},
+			expected: map[string]interface{}{
+				"exception.stacktrace": "01234...",
+			},
+		},
+		{
+			name:     "truncates on rune boundary",
+			maxBytes: 6,
+			input: map[string]interface{}{
+				// Each of these characters is 2 bytes long.
+				"exception.stacktrace": "żółwżółw",
+				// "€" is 3 bytes long.
+				"exception.message": "ab€€",
+			},
+			expected: map[string]interface{}{
+				"exception.stacktrace": "żół",
+				"exception.message":    "ab€",
+			},
+		},
+		{
+			name:     "truncates on rune boundary with suffix",
+			maxBytes: 7,
+			suffix:   "…",
+			input: map[string]interface{}{
+				"exception.message": "€€€",
+			},
+			expected: map[string]interface{}{
+				"exception.message": "€…",
+			},
+		},
+		{
+			name:     "leaves non-string values unchanged",
+			maxBytes: 1,
+			input: map[string]interface{}{
+				"exception.count": int64(123456),
+			},
+			expected: map[string]interface{}{
+				"exception.count": int64(123456),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			processor, err := newLimitAttributeLengthProcessor(&LimitAttributeLengthConfig{
+				Enabled:  true,
+				Patterns: []string{"exception.*"},
+				MaxBytes: testCase.maxBytes,
+				Suffix:   testCase.suffix,
+			})
+			require.NoError(t, err)
+
+			attributes := pcommon.NewMapFromRaw(testCase.input)
+			processor.processAttributes(attributes)
+
+			assert.Equal(t, testCase.expected, attributes.AsRaw())
+			attributes.Range(func(_ string, value pcommon.Value) bool {
+				assert.True(t, utf8.ValidString(value.AsString()))
+				return true
+			})
+		})
+	}
+}
+
+func TestLimitAttributeLengthInvalidConfig(t *testing.T) {
+	_, err := newLimitAttributeLengthProcessor(&LimitAttributeLengthConfig{
+		Enabled:  true,
+		MaxBytes: 3,
+		Suffix:   "...",
+	})
+	assert.EqualError(t, err, "suffix must be shorter than max_bytes")
+}
@@ -0,0 +1,159 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	minSeverityNumber = int(plog.SeverityNumberTRACE)
+	maxSeverityNumber = int(plog.SeverityNumberFATAL4)
+)
+
+// MapSeverityConfig configures the map_severity sub-processor.
+type MapSeverityConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Attribute is the key of the log record attribute the severity label is written to.
+	Attribute string `mapstructure:"attribute"`
+	// Ranges map severity numbers to labels. If empty, the ranges defined by OpenTelemetry are used.
+	Ranges []SeverityRange `mapstructure:"ranges"`
+	// Default is the label for log records without severity. If empty, these log records are skipped.
+	Default string `mapstructure:"default"`
+}
+
+// SeverityRange maps an inclusive range of severity numbers to a label.
+type SeverityRange struct {
+	Min   int    `mapstructure:"min"`
+	Max   int    `mapstructure:"max"`
+	Label string `mapstructure:"label"`
+}
+
+// defaultSeverityRanges are the severity ranges defined by the OpenTelemetry log data model.
+var defaultSeverityRanges = []SeverityRange{
+	{Min: int(plog.SeverityNumberTRACE), Max: int(plog.SeverityNumberTRACE4), Label: "TRACE"},
+	{Min: int(plog.SeverityNumberDEBUG), Max: int(plog.SeverityNumberDEBUG4), Label: "DEBUG"},
+	{Min: int(plog.SeverityNumberINFO), Max: int(plog.SeverityNumberINFO4), Label: "INFO"},
+	{Min: int(plog.SeverityNumberWARN), Max: int(plog.SeverityNumberWARN4), Label: "WARN"},
+	{Min: int(plog.SeverityNumberERROR), Max: int(plog.SeverityNumberERROR4), Label: "ERROR"},
+	{Min: int(plog.SeverityNumberFATAL), Max: int(plog.SeverityNumberFATAL4), Label: "FATAL"},
+}
+
+// mapSeverityProcessor writes a normalized severity label to log record attributes.
+type mapSeverityProcessor struct {
+	enabled      bool
+	attribute    string
+	ranges       []SeverityRange
+	defaultLabel string
+}
+
+func newMapSeverityProcessor(config *MapSeverityConfig) (*mapSeverityProcessor, error) {
+	if config.Enabled {
+		if err := validateMapSeverityConfig(config); err != nil {
+			return nil, err
+		}
+	}
+
+	ranges := config.Ranges
+	if len(ranges) == 0 {
+		ranges = defaultSeverityRanges
+	}
+
+	return &mapSeverityProcessor{
+		enabled:      config.Enabled,
+		attribute:    config.Attribute,
+		ranges:       ranges,
+		defaultLabel: config.Default,
+	}, nil
+}
+
+func validateMapSeverityConfig(config *MapSeverityConfig) error {
+	if config.Attribute == "" {
+		return errors.New("attribute must not be empty")
+	}
+
+	for i, severityRange := range config.Ranges {
+		if severityRange.Min < minSeverityNumber || severityRange.Max > maxSeverityNumber || severityRange.Min > severityRange.Max {
+			return fmt.Errorf("range %d: invalid severity range %d-%d", i, severityRange.Min, severityRange.Max)
+		}
+		if severityRange.Label == "" {
+			return fmt.Errorf("range %d: label must not be empty", i)
+		}
+	}
+
+	return nil
+}
+
+func (proc *mapSeverityProcessor) ProcessLogs(logs plog.Logs) error {
+	if proc.enabled {
+		processLogRecords(logs, proc.mapSeverity)
+	}
+	return nil
+}
+
+func (proc *mapSeverityProcessor) ProcessMetrics(_ pmetric.Metrics) error {
+	// No-op, this subprocessor doesn't process metrics.
+	return nil
+}
+
+func (proc *mapSeverityProcessor) ProcessTraces(_ ptrace.Traces) error {
+	// No-op, this subprocessor doesn't process traces.
+	return nil
+}
+
+func (proc *mapSeverityProcessor) IsEnabled() bool {
+	return proc.enabled
+}
+
+func (*mapSeverityProcessor) ConfigPropertyName() string {
+	return "map_severity"
+}
+
+func (proc *mapSeverityProcessor) mapSeverity(logRecord plog.LogRecord) {
+	if label, ok := proc.severityLabel(logRecord); ok {
+		logRecord.Attributes().UpsertString(proc.attribute, label)
+	}
+}
+
+// severityLabel returns the label for the severity number of the log record.
+// When the severity number is not set, the upper-cased severity text is used,
+// and when neither is set, the default label is used.
+func (proc *mapSeverityProcessor) severityLabel(logRecord plog.LogRecord) (string, bool) {
+	severityNumber := logRecord.SeverityNumber()
+	if severityNumber != plog.SeverityNumberUNDEFINED {
+		for _, severityRange := range proc.ranges {
+			if int(severityNumber) >= severityRange.Min && int(severityNumber) <= severityRange.Max {
+				return severityRange.Label, true
+			}
+		}
+		return "", false
+	}
+
+	if severityText := logRecord.SeverityText(); severityText != "" {
+		return strings.ToUpper(severityText), true
+	}
+
+	if proc.defaultLabel != "" {
+		return proc.defaultLabel, true
+	}
+
+	return "", false
+}
@@ -0,0 +1,154 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestMapSeverityDefaultRanges(t *testing.T) {
+	processor, err := newMapSeverityProcessor(&MapSeverityConfig{
+		Enabled:   true,
+		Attribute: "loglevel",
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		severityNumber plog.SeverityNumber
+		expected       string
+	}{
+		{severityNumber: plog.SeverityNumberTRACE, expected: "TRACE"},
+		{severityNumber: plog.SeverityNumberTRACE4, expected: "TRACE"},
+		{severityNumber: plog.SeverityNumberDEBUG, expected: "DEBUG"},
+		{severityNumber: plog.SeverityNumberDEBUG4, expected: "DEBUG"},
+		{severityNumber: plog.SeverityNumberINFO, expected: "INFO"},
+		{severityNumber: plog.SeverityNumberINFO4, expected: "INFO"},
+		{severityNumber: plog.SeverityNumberWARN, expected: "WARN"},
+		{severityNumber: plog.SeverityNumberWARN4, expected: "WARN"},
+		{severityNumber: plog.SeverityNumberERROR, expected: "ERROR"},
+		{severityNumber: plog.SeverityNumberERROR4, expected: "ERROR"},
+		{severityNumber: plog.SeverityNumberFATAL, expected: "FATAL"},
+		{severityNumber: plog.SeverityNumberFATAL4, expected: "FATAL"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.severityNumber.String(), func(t *testing.T) {
+			logRecord := plog.NewLogRecord()
+			logRecord.SetSeverityNumber(testCase.severityNumber)
+
+			processor.mapSeverity(logRecord)
+
+			assertAttribute(t, logRecord.Attributes(), "loglevel", testCase.expected)
+		})
+	}
+}
+
+func TestMapSeverityCustomRanges(t *testing.T) {
+	processor, err := newMapSeverityProcessor(&MapSeverityConfig{
+		Enabled:   true,
+		Attribute: "level",
+		Ranges: []SeverityRange{
+			{Min: 1, Max: 12, Label: "low"},
+			{Min: 17, Max: 24, Label: "high"},
+		},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		severityNumber plog.SeverityNumber
+		expected       string
+	}{
+		{severityNumber: 1, expected: "low"},
+		{severityNumber: 12, expected: "low"},
+		{severityNumber: 13, expected: ""},
+		{severityNumber: 16, expected: ""},
+		{severityNumber: 17, expected: "high"},
+		{severityNumber: 24, expected: "high"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.severityNumber.String(), func(t *testing.T) {
+			logRecord := plog.NewLogRecord()
+			logRecord.SetSeverityNumber(testCase.severityNumber)
+
+			processor.mapSeverity(logRecord)
+
+			assertAttribute(t, logRecord.Attributes(), "level", testCase.expected)
+		})
+	}
+}
+
+func TestMapSeverityUnsetSeverity(t *testing.T) {
+	testCases := []struct {
+		name         string
+		severityText string
+		defaultLabel string
+		expected     string
+	}{
+		{
+			name:         "uses severity text",
+			severityText: "warning",
+			defaultLabel: "UNKNOWN",
+			expected:     "WARNING",
+		},
+		{
+			name:         "uses default",
+			defaultLabel: "UNKNOWN",
+			expected:     "UNKNOWN",
+		},
+		{
+			name:     "skips without default",
+			expected: "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			processor, err := newMapSeverityProcessor(&MapSeverityConfig{
+				Enabled:   true,
+				Attribute: "loglevel",
+				Default:   testCase.defaultLabel,
+			})
+			require.NoError(t, err)
+
+			logRecord := plog.NewLogRecord()
+			logRecord.SetSeverityText(testCase.severityText)
+
+			processor.mapSeverity(logRecord)
+
+			assertAttribute(t, logRecord.Attributes(), "loglevel", testCase.expected)
+		})
+	}
+}
+
+func TestMapSeverityProcessLogs(t *testing.T) {
+	processor, err := newMapSeverityProcessor(&MapSeverityConfig{
+		Enabled:   true,
+		Attribute: "loglevel",
+	})
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	logRecord := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	logRecord.SetSeverityNumber(plog.SeverityNumberERROR2)
+	logRecord.Attributes().InsertString("loglevel", "debug")
+
+	require.NoError(t, processor.ProcessLogs(logs))
+
+	assertAttribute(t, logRecord.Attributes(), "loglevel", "ERROR")
+}
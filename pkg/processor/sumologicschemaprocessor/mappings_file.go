@@ -0,0 +1,136 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultMappingsFileWatchInterval = 10 * time.Second
+
+// mappingsFile loads a map of attribute keys from a YAML or JSON file,
+// and optionally reloads it whenever the file changes.
+type mappingsFile struct {
+	logger *zap.Logger
+	path   string
+	watch  bool
+	// interval is how often the file is checked for changes when watching.
+	interval time.Duration
+
+	done     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// newMappingsFile returns nil if the path is empty.
+func newMappingsFile(path string, watch bool, logger *zap.Logger) *mappingsFile {
+	if path == "" {
+		return nil
+	}
+
+	return &mappingsFile{
+		logger:   logger,
+		path:     path,
+		watch:    watch,
+		interval: defaultMappingsFileWatchInterval,
+		done:     make(chan struct{}),
+	}
+}
+
+// start loads the file and passes the mappings to apply. If watching is enabled, it also starts a goroutine
+// which calls apply again every time the file changes. If the changed file cannot be loaded,
+// the error is logged and the previously applied mappings are kept.
+func (file *mappingsFile) start(apply func(map[string]string)) error {
+	mappings, modTime, err := file.load()
+	if err != nil {
+		return err
+	}
+	apply(mappings)
+
+	if !file.watch {
+		return nil
+	}
+
+	file.wg.Add(1)
+	go func() {
+		defer file.wg.Done()
+
+		ticker := time.NewTicker(file.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-file.done:
+				return
+			case <-ticker.C:
+				info, err := os.Stat(file.path)
+				if err != nil || info.ModTime().Equal(modTime) {
+					continue
+				}
+
+				mappings, newModTime, err := file.load()
+				if err != nil {
+					file.logger.Warn("Failed to reload mappings file, keeping previous mappings", zap.Error(err))
+					modTime = info.ModTime()
+					continue
+				}
+				modTime = newModTime
+				apply(mappings)
+				file.logger.Info("Reloaded mappings file", zap.String("path", file.path))
+			}
+		}
+	}()
+
+	return nil
+}
+
+// shutdown stops watching the file. It can be called more than once.
+func (file *mappingsFile) shutdown() {
+	file.stopOnce.Do(func() {
+		close(file.done)
+	})
+	file.wg.Wait()
+}
+
+func (file *mappingsFile) load() (map[string]string, time.Time, error) {
+	info, err := os.Stat(file.path)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to read mappings file: %w", err)
+	}
+
+	content, err := os.ReadFile(file.path)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to read mappings file: %w", err)
+	}
+
+	mappings := map[string]string{}
+	if err := yaml.Unmarshal(content, &mappings); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to parse mappings file %s: %w", file.path, err)
+	}
+
+	for oldName, newName := range mappings {
+		if oldName == "" || newName == "" {
+			return nil, time.Time{}, fmt.Errorf("mappings file %s contains an empty name", file.path)
+		}
+	}
+
+	return mappings, info.ModTime(), nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+)
+
+func TestMappingsFileLoad(t *testing.T) {
+	testCases := []struct {
+		name        string
+		content     string
+		expected    map[string]string
+		expectedErr string
+	}{
+		{
+			name:     "yaml",
+			content:  "pod: k8s.pod.name\nnode: k8s.node.name\n",
+			expected: map[string]string{"pod": "k8s.pod.name", "node": "k8s.node.name"},
+		},
+		{
+			name:     "json",
+			content:  `{"pod": "k8s.pod.name"}`,
+			expected: map[string]string{"pod": "k8s.pod.name"},
+		},
+		{
+			name:        "not a map",
+			content:     "- pod\n- node\n",
+			expectedErr: "failed to parse mappings file",
+		},
+		{
+			name:        "empty value",
+			content:     "pod: ''\n",
+			expectedErr: "contains an empty name",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "mappings")
+			require.NoError(t, os.WriteFile(path, []byte(testCase.content), 0600))
+
+			mappings, _, err := newMappingsFile(path, false, zap.NewNop()).load()
+			if testCase.expectedErr != "" {
+				assert.ErrorContains(t, err, testCase.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, mappings)
+		})
+	}
+}
+
+func TestMappingsFileWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mappings.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("pod: k8s.pod.name\n"), 0600))
+
+	file := newMappingsFile(path, true, zap.NewNop())
+	file.interval = 10 * time.Millisecond
+
+	var mutex sync.Mutex
+	var current map[string]string
+	require.NoError(t, file.start(func(mappings map[string]string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		current = mappings
+	}))
+	defer file.shutdown()
+
+	mutex.Lock()
+	assert.Equal(t, map[string]string{"pod": "k8s.pod.name"}, current)
+	mutex.Unlock()
+
+	require.NoError(t, os.WriteFile(path, []byte("pod: pod_name\n"), 0600))
+	// Make sure the modification time changes even on file systems with coarse timestamps.
+	modTime := time.Now().Add(time.Second)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+
+	assert.Eventually(t, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return current["pod"] == "pod_name"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestMappingsFileShutdownTwice(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mappings.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("pod: k8s.pod.name\n"), 0600))
+
+	renameProcessor, err := newRenameAttributesProcessor(&RenameAttributesConfig{
+		Enabled:      true,
+		MappingsFile: path,
+		Watch:        true,
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, renameProcessor.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, renameProcessor.Shutdown(context.Background()))
+	require.NoError(t, renameProcessor.Shutdown(context.Background()))
+
+	config := createDefaultConfig().(*Config)
+	translateProcessor, err := newTranslateAttributesProcessor(true, nil, config.TranslateAttributesDirection, nil,
+		config.TranslateAttributesScope, newMappingsFile(path, true, zap.NewNop()), zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, translateProcessor.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, translateProcessor.Shutdown(context.Background()))
+	require.NoError(t, translateProcessor.Shutdown(context.Background()))
+}
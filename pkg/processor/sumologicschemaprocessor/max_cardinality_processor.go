@@ -0,0 +1,238 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	maxCardinalityActionDrop    = "drop"
+	maxCardinalityActionReplace = "replace"
+
+	// maxCardinalityCleanupsPerWindow is how many times per window expired values are cleaned up,
+	// so that a value counts towards the limit for at most a tenth of the window longer than it should.
+	maxCardinalityCleanupsPerWindow = 10
+)
+
+// MaxCardinalityConfig configures the max_cardinality sub-processor.
+type MaxCardinalityConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Keys are attribute keys whose distinct values are counted.
+	Keys []string `mapstructure:"keys"`
+	// Limit is the maximum number of distinct values of a key within Window.
+	Limit int `mapstructure:"limit"`
+	// Window is how long a value counts towards the limit after it was last seen.
+	Window time.Duration `mapstructure:"window"`
+	// Action is either `drop` or `replace`.
+	Action string `mapstructure:"action"`
+	// Placeholder replaces values over the limit when Action is `replace`.
+	Placeholder string `mapstructure:"placeholder"`
+}
+
+// maxCardinalityProcessor drops or replaces values of attributes which have too many distinct values.
+// Unlike other sub-processors, it keeps state between batches, which is guarded by a lock.
+type maxCardinalityProcessor struct {
+	enabled     bool
+	keys        []string
+	limit       int
+	window      time.Duration
+	replace     bool
+	placeholder string
+	// now returns the current time, it is replaced in tests.
+	now func() time.Time
+
+	lock sync.Mutex
+	// seen maps every key to its values seen within the window and the time they were last seen at.
+	seen map[string]map[string]time.Time
+
+	done     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+func newMaxCardinalityProcessor(config *MaxCardinalityConfig) (*maxCardinalityProcessor, error) {
+	if config.Enabled {
+		if err := validateMaxCardinalityConfig(config); err != nil {
+			return nil, err
+		}
+	}
+
+	seen := make(map[string]map[string]time.Time, len(config.Keys))
+	for _, key := range config.Keys {
+		seen[key] = map[string]time.Time{}
+	}
+
+	return &maxCardinalityProcessor{
+		enabled:     config.Enabled,
+		keys:        config.Keys,
+		limit:       config.Limit,
+		window:      config.Window,
+		replace:     config.Action == maxCardinalityActionReplace,
+		placeholder: config.Placeholder,
+		now:         time.Now,
+		seen:        seen,
+		done:        make(chan struct{}),
+	}, nil
+}
+
+func validateMaxCardinalityConfig(config *MaxCardinalityConfig) error {
+	if len(config.Keys) == 0 {
+		return errors.New("keys must not be empty")
+	}
+	if config.Limit <= 0 {
+		return fmt.Errorf("limit must be positive, got %d", config.Limit)
+	}
+	if config.Window <= 0 {
+		return fmt.Errorf("window must be positive, got %s", config.Window)
+	}
+
+	switch config.Action {
+	case maxCardinalityActionDrop:
+	case maxCardinalityActionReplace:
+		if config.Placeholder == "" {
+			return errors.New("placeholder must not be empty when action is replace")
+		}
+	default:
+		return fmt.Errorf("invalid action: %q", config.Action)
+	}
+
+	return nil
+}
+
+// Start starts a goroutine which forgets values not seen within the window.
+func (proc *maxCardinalityProcessor) Start(_ context.Context, _ component.Host) error {
+	interval := proc.window / maxCardinalityCleanupsPerWindow
+	if interval <= 0 {
+		interval = proc.window
+	}
+
+	proc.wg.Add(1)
+	go func() {
+		defer proc.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-proc.done:
+				return
+			case <-ticker.C:
+				proc.cleanup()
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Shutdown stops the cleanup goroutine. It can be called more than once.
+func (proc *maxCardinalityProcessor) Shutdown(_ context.Context) error {
+	proc.stopOnce.Do(func() {
+		close(proc.done)
+	})
+	proc.wg.Wait()
+	return nil
+}
+
+func (proc *maxCardinalityProcessor) ProcessLogs(logs plog.Logs) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnLogs(proc, logs)
+	}
+	return nil
+}
+
+func (proc *maxCardinalityProcessor) ProcessMetrics(metrics pmetric.Metrics) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnMetrics(proc, metrics)
+	}
+	return nil
+}
+
+func (proc *maxCardinalityProcessor) ProcessTraces(traces ptrace.Traces) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnTraces(proc, traces)
+	}
+	return nil
+}
+
+func (proc *maxCardinalityProcessor) IsEnabled() bool {
+	return proc.enabled
+}
+
+func (*maxCardinalityProcessor) ConfigPropertyName() string {
+	return "max_cardinality"
+}
+
+func (proc *maxCardinalityProcessor) processAttributes(attributes pcommon.Map) int {
+	now := proc.now()
+
+	removed := 0
+	for _, key := range proc.keys {
+		value, found := attributes.Get(key)
+		if !found || proc.admit(key, value.AsString(), now) {
+			continue
+		}
+
+		if proc.replace {
+			attributes.UpsertString(key, proc.placeholder)
+		} else {
+			attributes.Remove(key)
+			removed++
+		}
+	}
+	return removed
+}
+
+// admit records the value of the key as seen and returns whether it is within the limit.
+// Values already seen within the window are always admitted.
+func (proc *maxCardinalityProcessor) admit(key string, value string, now time.Time) bool {
+	proc.lock.Lock()
+	defer proc.lock.Unlock()
+
+	values := proc.seen[key]
+	if _, ok := values[value]; !ok && len(values) >= proc.limit {
+		return false
+	}
+	values[value] = now
+	return true
+}
+
+// cleanup forgets values which were last seen before the window.
+func (proc *maxCardinalityProcessor) cleanup() {
+	expired := proc.now().Add(-proc.window)
+
+	proc.lock.Lock()
+	defer proc.lock.Unlock()
+
+	for _, values := range proc.seen {
+		for value, lastSeen := range values {
+			if !lastSeen.After(expired) {
+				delete(values, value)
+			}
+		}
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// processUserIDs runs the processor on an attribute map for each of the user IDs
+// and returns the resulting values of `user.id`, or nil for removed attributes.
+func processUserIDs(processor *maxCardinalityProcessor, userIDs ...string) []interface{} {
+	result := make([]interface{}, 0, len(userIDs))
+	for _, userID := range userIDs {
+		attributes := pcommon.NewMapFromRaw(map[string]interface{}{"user.id": userID, "host": "a"})
+		processor.processAttributes(attributes)
+
+		value, found := attributes.Get("user.id")
+		if !found {
+			result = append(result, nil)
+			continue
+		}
+		result = append(result, value.AsString())
+	}
+	return result
+}
+
+func TestMaxCardinality(t *testing.T) {
+	testCases := []struct {
+		name     string
+		action   string
+		expected []interface{}
+	}{
+		{
+			name:     "drop",
+			action:   "drop",
+			expected: []interface{}{"a", "b", "a", nil, nil, "b"},
+		},
+		{
+			name:     "replace",
+			action:   "replace",
+			expected: []interface{}{"a", "b", "a", "overflow", "overflow", "b"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			processor, err := newMaxCardinalityProcessor(&MaxCardinalityConfig{
+				Enabled:     true,
+				Keys:        []string{"user.id"},
+				Limit:       2,
+				Window:      time.Minute,
+				Action:      testCase.action,
+				Placeholder: "overflow",
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expected, processUserIDs(processor, "a", "b", "a", "c", "d", "b"))
+		})
+	}
+}
+
+func TestMaxCardinalityWindow(t *testing.T) {
+	processor, err := newMaxCardinalityProcessor(&MaxCardinalityConfig{
+		Enabled: true,
+		Keys:    []string{"user.id"},
+		Limit:   2,
+		Window:  time.Minute,
+		Action:  "drop",
+	})
+	require.NoError(t, err)
+
+	now := time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)
+	processor.now = func() time.Time { return now }
+
+	assert.Equal(t, []interface{}{"a", "b", nil}, processUserIDs(processor, "a", "b", "c"))
+
+	// `a` is seen again, so only `b` expires.
+	now = now.Add(40 * time.Second)
+	assert.Equal(t, []interface{}{"a"}, processUserIDs(processor, "a"))
+	now = now.Add(20 * time.Second)
+	processor.cleanup()
+	assert.Equal(t, []interface{}{"c", nil}, processUserIDs(processor, "c", "b"))
+
+	now = now.Add(time.Minute)
+	processor.cleanup()
+	assert.Equal(t, []interface{}{"d", "e"}, processUserIDs(processor, "d", "e"))
+}
+
+func TestMaxCardinalityGrowth(t *testing.T) {
+	processor, err := newMaxCardinalityProcessor(&MaxCardinalityConfig{
+		Enabled: true,
+		Keys:    []string{"user.id"},
+		Limit:   100,
+		Window:  time.Hour,
+		Action:  "drop",
+	})
+	require.NoError(t, err)
+
+	metrics := pmetric.NewMetrics()
+	metric := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetDataType(pmetric.MetricDataTypeSum)
+	for i := 0; i < 1000; i++ {
+		metric.Sum().DataPoints().AppendEmpty().Attributes().InsertString("user.id", fmt.Sprintf("user-%d", i))
+	}
+	require.NoError(t, processor.ProcessMetrics(metrics))
+
+	kept := 0
+	dataPoints := metric.Sum().DataPoints()
+	for i := 0; i < dataPoints.Len(); i++ {
+		if _, found := dataPoints.At(i).Attributes().Get("user.id"); found {
+			kept++
+		}
+	}
+	assert.Equal(t, 100, kept)
+	assert.Len(t, processor.seen["user.id"], 100)
+}
+
+func TestMaxCardinalityStartShutdown(t *testing.T) {
+	processor, err := newMaxCardinalityProcessor(&MaxCardinalityConfig{
+		Enabled: true,
+		Keys:    []string{"user.id"},
+		Limit:   1,
+		Window:  time.Millisecond,
+		Action:  "drop",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, processor.Start(context.Background(), componenttest.NewNopHost()))
+	processUserIDs(processor, "a")
+	assert.Eventually(t, func() bool {
+		return processUserIDs(processor, "b")[0] == "b"
+	}, time.Second, time.Millisecond)
+	require.NoError(t, processor.Shutdown(context.Background()))
+	require.NoError(t, processor.Shutdown(context.Background()))
+}
+
+func TestMaxCardinalityShutdownWithoutStart(t *testing.T) {
+	processor, err := newMaxCardinalityProcessor(&MaxCardinalityConfig{
+		Enabled: true,
+		Keys:    []string{"user.id"},
+		Limit:   1,
+		Window:  time.Minute,
+		Action:  "drop",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, processor.Shutdown(context.Background()))
+	require.NoError(t, processor.Shutdown(context.Background()))
+}
+
+func TestMaxCardinalityInvalidConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      MaxCardinalityConfig
+		expectedErr string
+	}{
+		{
+			name:        "no keys",
+			config:      MaxCardinalityConfig{Limit: 1, Window: time.Minute, Action: "drop"},
+			expectedErr: "keys must not be empty",
+		},
+		{
+			name:        "zero limit",
+			config:      MaxCardinalityConfig{Keys: []string{"a"}, Window: time.Minute, Action: "drop"},
+			expectedErr: "limit must be positive, got 0",
+		},
+		{
+			name:        "zero window",
+			config:      MaxCardinalityConfig{Keys: []string{"a"}, Limit: 1, Action: "drop"},
+			expectedErr: "window must be positive, got 0s",
+		},
+		{
+			name:        "invalid action",
+			config:      MaxCardinalityConfig{Keys: []string{"a"}, Limit: 1, Window: time.Minute, Action: "hash"},
+			expectedErr: `invalid action: "hash"`,
+		},
+		{
+			name:        "replace without placeholder",
+			config:      MaxCardinalityConfig{Keys: []string{"a"}, Limit: 1, Window: time.Minute, Action: "replace"},
+			expectedErr: "placeholder must not be empty when action is replace",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.config.Enabled = true
+			_, err := newMaxCardinalityProcessor(&testCase.config)
+			assert.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
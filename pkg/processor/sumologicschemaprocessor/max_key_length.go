@@ -0,0 +1,142 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const (
+	// loggedKeyPrefixLength is the number of bytes of an oversized key which are logged.
+	loggedKeyPrefixLength = 64
+
+	maxKeyLengthActionSkip   = "skip"
+	maxKeyLengthActionRemove = "remove"
+)
+
+// keyMatchingSubprocessor is implemented by sub-processors which match attribute keys against patterns.
+// The processor passes max_key_length to them, so that longer keys are never matched.
+type keyMatchingSubprocessor interface {
+	setMaxKeyLength(maxKeyLength int)
+}
+
+// exceedsMaxKeyLength returns true if the key is longer than the limit. Zero means no limit.
+func exceedsMaxKeyLength(key string, maxKeyLength int) bool {
+	return maxKeyLength > 0 && len(key) > maxKeyLength
+}
+
+// maxKeyLengthGuard logs attributes with keys longer than the configured limit, which other sub-processors
+// don't match against their patterns. With the `remove` action, it also removes these attributes.
+// It always runs first and is not affected by `processor_order` or `conditions`.
+type maxKeyLengthGuard struct {
+	maxKeyLength int
+	remove       bool
+	logger       *zap.Logger
+}
+
+func newMaxKeyLengthGuard(maxKeyLength int, action string, logger *zap.Logger) (*maxKeyLengthGuard, error) {
+	if err := validateMaxKeyLength(maxKeyLength, action); err != nil {
+		return nil, err
+	}
+
+	return &maxKeyLengthGuard{
+		maxKeyLength: maxKeyLength,
+		remove:       action == maxKeyLengthActionRemove,
+		logger:       logger,
+	}, nil
+}
+
+func validateMaxKeyLength(maxKeyLength int, action string) error {
+	if maxKeyLength < 0 {
+		return fmt.Errorf("max_key_length must not be negative, got %d", maxKeyLength)
+	}
+	switch action {
+	case maxKeyLengthActionSkip, maxKeyLengthActionRemove:
+		return nil
+	default:
+		return fmt.Errorf("max_key_length_action: invalid action: %q", action)
+	}
+}
+
+func (guard *maxKeyLengthGuard) ProcessLogs(logs plog.Logs) error {
+	if guard.IsEnabled() {
+		runAttributesSubprocessorOnLogs(guard, logs)
+	}
+	return nil
+}
+
+func (guard *maxKeyLengthGuard) ProcessMetrics(metrics pmetric.Metrics) error {
+	if guard.IsEnabled() {
+		runAttributesSubprocessorOnMetrics(guard, metrics)
+	}
+	return nil
+}
+
+func (guard *maxKeyLengthGuard) ProcessTraces(traces ptrace.Traces) error {
+	if guard.IsEnabled() {
+		runAttributesSubprocessorOnTraces(guard, traces)
+	}
+	return nil
+}
+
+func (guard *maxKeyLengthGuard) IsEnabled() bool {
+	return guard.maxKeyLength > 0
+}
+
+func (*maxKeyLengthGuard) ConfigPropertyName() string {
+	return "max_key_length"
+}
+
+func (guard *maxKeyLengthGuard) processAttributes(attributes pcommon.Map) int {
+	if !guard.remove {
+		attributes.Range(func(key string, _ pcommon.Value) bool {
+			if exceedsMaxKeyLength(key, guard.maxKeyLength) {
+				guard.logOversizedKey("Skipping matching of attribute with a key exceeding max_key_length", key)
+			}
+			return true
+		})
+		return 0
+	}
+
+	removed := 0
+	attributes.RemoveIf(func(key string, _ pcommon.Value) bool {
+		if !exceedsMaxKeyLength(key, guard.maxKeyLength) {
+			return false
+		}
+
+		guard.logOversizedKey("Removing attribute with a key exceeding max_key_length", key)
+		removed++
+		return true
+	})
+	return removed
+}
+
+func (guard *maxKeyLengthGuard) logOversizedKey(message string, key string) {
+	prefix := key
+	if len(prefix) > loggedKeyPrefixLength {
+		prefix = prefix[:loggedKeyPrefixLength]
+	}
+	guard.logger.Debug(message,
+		zap.String("key_prefix", prefix),
+		zap.Int("key_length", len(key)),
+		zap.Int("max_key_length", guard.maxKeyLength),
+	)
+}
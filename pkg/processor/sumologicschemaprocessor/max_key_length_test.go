@@ -0,0 +1,133 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestMaxKeyLength(t *testing.T) {
+	core, observedLogs := observer.New(zapcore.DebugLevel)
+	settings := component.ProcessorCreateSettings{
+		TelemetrySettings: component.TelemetrySettings{
+			Logger: zap.New(core),
+		},
+	}
+
+	config := createDefaultConfig().(*Config)
+	config.TranslateAttributes = false
+	config.MaxKeyLength = 16
+	config.RedactAttributes.Enabled = true
+	config.RedactAttributes.Patterns = []string{"*"}
+	config.RedactAttributes.Action = redactActionMask
+	config.RewriteKeys.Enabled = true
+	config.RewriteKeys.Pattern = "k"
+	config.RewriteKeys.Replacement = "x"
+
+	processor, err := newSumologicSchemaProcessor(settings, config)
+	require.NoError(t, err)
+
+	oversizedKey := strings.Repeat("k", 1<<20)
+	logs := plog.NewLogs()
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	resourceLogs.Resource().Attributes().InsertString(oversizedKey, "value")
+	attributes := resourceLogs.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes()
+	attributes.InsertString("password", "secret")
+	attributes.InsertString(oversizedKey, "value")
+	attributes.InsertString(strings.Repeat("k", 16), "value")
+
+	_, err = processor.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+
+	// Attributes with oversized keys are kept, but not matched by any sub-processor.
+	assert.Equal(t, map[string]interface{}{oversizedKey: "value"}, resourceLogs.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{
+		"password":              "******",
+		oversizedKey:            "value",
+		strings.Repeat("x", 16): "*****",
+	}, attributes.AsRaw())
+
+	entries := observedLogs.FilterMessage("Skipping matching of attribute with a key exceeding max_key_length").All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, strings.Repeat("k", loggedKeyPrefixLength), entries[0].ContextMap()["key_prefix"])
+	assert.Equal(t, int64(1<<20), entries[0].ContextMap()["key_length"])
+}
+
+func TestMaxKeyLengthRemove(t *testing.T) {
+	core, observedLogs := observer.New(zapcore.DebugLevel)
+	settings := component.ProcessorCreateSettings{
+		TelemetrySettings: component.TelemetrySettings{
+			Logger: zap.New(core),
+		},
+	}
+
+	config := createDefaultConfig().(*Config)
+	config.TranslateAttributes = false
+	config.MaxKeyLength = 16
+	config.MaxKeyLengthAction = maxKeyLengthActionRemove
+	config.RedactAttributes.Enabled = true
+	config.RedactAttributes.Patterns = []string{"*"}
+	config.RedactAttributes.Action = redactActionMask
+
+	processor, err := newSumologicSchemaProcessor(settings, config)
+	require.NoError(t, err)
+
+	oversizedKey := strings.Repeat("k", 1<<20)
+	logs := plog.NewLogs()
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	resourceLogs.Resource().Attributes().InsertString(oversizedKey, "value")
+	attributes := resourceLogs.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes()
+	attributes.InsertString("password", "secret")
+	attributes.InsertString(oversizedKey, "value")
+	attributes.InsertString(strings.Repeat("k", 16), "value")
+
+	_, err = processor.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, resourceLogs.Resource().Attributes().Len())
+	assert.Equal(t, map[string]interface{}{
+		"password":              "******",
+		strings.Repeat("k", 16): "*****",
+	}, attributes.AsRaw())
+
+	entries := observedLogs.FilterMessage("Removing attribute with a key exceeding max_key_length").All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, strings.Repeat("k", loggedKeyPrefixLength), entries[0].ContextMap()["key_prefix"])
+	assert.Equal(t, int64(1<<20), entries[0].ContextMap()["key_length"])
+}
+
+func TestMaxKeyLengthDisabled(t *testing.T) {
+	guard, err := newMaxKeyLengthGuard(0, maxKeyLengthActionSkip, zap.NewNop())
+	require.NoError(t, err)
+	assert.False(t, guard.IsEnabled())
+}
+
+func TestMaxKeyLengthInvalidConfig(t *testing.T) {
+	_, err := newMaxKeyLengthGuard(-1, maxKeyLengthActionSkip, zap.NewNop())
+	assert.EqualError(t, err, "max_key_length must not be negative, got -1")
+
+	_, err = newMaxKeyLengthGuard(16, "truncate", zap.NewNop())
+	assert.EqualError(t, err, `max_key_length_action: invalid action: "truncate"`)
+}
@@ -0,0 +1,258 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	moveDirectionRecordToResource = "record_to_resource"
+	moveDirectionResourceToRecord = "resource_to_record"
+
+	// moveDifferingValuesSkip leaves the attribute on the records when records of a resource have different values.
+	moveDifferingValuesSkip = "skip"
+	// moveDifferingValuesFirst moves the value of the first record when records of a resource have different values.
+	moveDifferingValuesFirst = "first"
+	// moveDifferingValuesLast moves the value of the last record when records of a resource have different values.
+	moveDifferingValuesLast = "last"
+)
+
+// MoveAttributesConfig configures the move_attributes sub-processor.
+type MoveAttributesConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Patterns are attribute keys to move. The `*` character matches any sequence of characters.
+	Patterns []string `mapstructure:"patterns"`
+	// WildcardOptions change how Patterns are matched.
+	WildcardOptions `mapstructure:",squash"`
+	// Direction is either `record_to_resource` or `resource_to_record`.
+	Direction string `mapstructure:"direction"`
+	// DifferingValues defines what happens when records of a resource have different values of an attribute
+	// moved to the resource, one of `skip`, `first` or `last`.
+	DifferingValues string `mapstructure:"differing_values"`
+	// Overwrite defines whether an attribute which already exists at the target should be overwritten.
+	Overwrite bool `mapstructure:"overwrite"`
+}
+
+// moveAttributesProcessor moves attributes between resources and their records.
+type moveAttributesProcessor struct {
+	enabled      bool
+	regexes      []*regexp.Regexp
+	maxKeyLength int
+	toRecord     bool
+	differing    string
+	overwrite    bool
+}
+
+func newMoveAttributesProcessor(config *MoveAttributesConfig) (*moveAttributesProcessor, error) {
+	if config.Enabled {
+		if err := validateMoveAttributesConfig(config); err != nil {
+			return nil, err
+		}
+	}
+
+	regexes, err := compileWildcards(config.Patterns, config.WildcardOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &moveAttributesProcessor{
+		enabled:   config.Enabled,
+		regexes:   regexes,
+		toRecord:  config.Direction == moveDirectionResourceToRecord,
+		differing: config.DifferingValues,
+		overwrite: config.Overwrite,
+	}, nil
+}
+
+func validateMoveAttributesConfig(config *MoveAttributesConfig) error {
+	switch config.Direction {
+	case moveDirectionRecordToResource, moveDirectionResourceToRecord:
+	default:
+		return fmt.Errorf("invalid direction: %q", config.Direction)
+	}
+
+	switch config.DifferingValues {
+	case moveDifferingValuesSkip, moveDifferingValuesFirst, moveDifferingValuesLast:
+	default:
+		return fmt.Errorf("invalid differing_values strategy: %q", config.DifferingValues)
+	}
+
+	_, err := compileWildcards(config.Patterns, config.WildcardOptions)
+	return err
+}
+
+func (proc *moveAttributesProcessor) ProcessLogs(logs plog.Logs) error {
+	if proc.enabled {
+		removed := 0
+		processLogsAttributesByResource(logs, func(resourceAttributes pcommon.Map, recordsAttributes []pcommon.Map) {
+			removed += proc.moveAttributes(resourceAttributes, recordsAttributes)
+		})
+		recordAttributesRemoved(proc.ConfigPropertyName(), signalLogs, removed)
+	}
+	return nil
+}
+
+func (proc *moveAttributesProcessor) ProcessMetrics(metrics pmetric.Metrics) error {
+	if proc.enabled {
+		removed := 0
+		processMetricsAttributesByResource(metrics, func(resourceAttributes pcommon.Map, recordsAttributes []pcommon.Map) {
+			removed += proc.moveAttributes(resourceAttributes, recordsAttributes)
+		})
+		recordAttributesRemoved(proc.ConfigPropertyName(), signalMetrics, removed)
+	}
+	return nil
+}
+
+func (proc *moveAttributesProcessor) ProcessTraces(traces ptrace.Traces) error {
+	if proc.enabled {
+		removed := 0
+		processTracesAttributesByResource(traces, func(resourceAttributes pcommon.Map, recordsAttributes []pcommon.Map) {
+			removed += proc.moveAttributes(resourceAttributes, recordsAttributes)
+		})
+		recordAttributesRemoved(proc.ConfigPropertyName(), signalTraces, removed)
+	}
+	return nil
+}
+
+func (proc *moveAttributesProcessor) IsEnabled() bool {
+	return proc.enabled
+}
+
+func (*moveAttributesProcessor) ConfigPropertyName() string {
+	return "move_attributes"
+}
+
+func (proc *moveAttributesProcessor) setMaxKeyLength(maxKeyLength int) {
+	proc.maxKeyLength = maxKeyLength
+}
+
+func (*moveAttributesProcessor) writesResource() {}
+
+// moveAttributes returns the number of attributes whose values were lost, not counting the moved ones.
+func (proc *moveAttributesProcessor) moveAttributes(resourceAttributes pcommon.Map, recordsAttributes []pcommon.Map) int {
+	if proc.toRecord {
+		return proc.moveToRecords(resourceAttributes, recordsAttributes)
+	}
+	return proc.moveToResource(resourceAttributes, recordsAttributes)
+}
+
+// moveToRecords copies matching resource attributes to all records and removes them from the resource.
+// Resources without records are left unchanged, so that no attributes are lost.
+// Overwritten record attributes are counted as removed, as is a resource attribute which no record received.
+func (proc *moveAttributesProcessor) moveToRecords(resourceAttributes pcommon.Map, recordsAttributes []pcommon.Map) int {
+	if len(recordsAttributes) == 0 {
+		return 0
+	}
+
+	keys := []string{}
+	resourceAttributes.Range(func(key string, _ pcommon.Value) bool {
+		if matchesAnyKeyRegex(proc.regexes, key, proc.maxKeyLength) {
+			keys = append(keys, key)
+		}
+		return true
+	})
+
+	removed := 0
+	for _, key := range keys {
+		value, _ := resourceAttributes.Get(key)
+		received := false
+		for _, attributes := range recordsAttributes {
+			_, exists := attributes.Get(key)
+			switch {
+			case !exists:
+				attributes.Insert(key, value)
+				received = true
+			case proc.overwrite:
+				attributes.Upsert(key, value)
+				received = true
+				removed++
+			}
+		}
+		resourceAttributes.Remove(key)
+		if !received {
+			removed++
+		}
+	}
+	return removed
+}
+
+// movedValue is a value of an attribute moved from records to their resource.
+type movedValue struct {
+	value      pcommon.Value
+	conflicted bool
+}
+
+// moveToResource moves matching record attributes to the resource and removes them from all records.
+// Records without the attribute do not cause a conflict.
+// An overwritten resource attribute and record attributes with other values than the moved one are counted as removed.
+func (proc *moveAttributesProcessor) moveToResource(resourceAttributes pcommon.Map, recordsAttributes []pcommon.Map) int {
+	keys := []string{}
+	values := map[string]*movedValue{}
+
+	for _, attributes := range recordsAttributes {
+		attributes.Range(func(key string, value pcommon.Value) bool {
+			if !matchesAnyKeyRegex(proc.regexes, key, proc.maxKeyLength) {
+				return true
+			}
+
+			moved, found := values[key]
+			if !found {
+				keys = append(keys, key)
+				values[key] = &movedValue{value: value}
+				return true
+			}
+			if !moved.value.Equal(value) {
+				moved.conflicted = true
+				if proc.differing == moveDifferingValuesLast {
+					moved.value = value
+				}
+			}
+			return true
+		})
+	}
+
+	removed := 0
+	for _, key := range keys {
+		moved := values[key]
+		if moved.conflicted && proc.differing == moveDifferingValuesSkip {
+			continue
+		}
+		_, exists := resourceAttributes.Get(key)
+		if exists && !proc.overwrite {
+			continue
+		}
+		if exists {
+			removed++
+		}
+
+		// The moved value belongs to one of the records, so it is copied before removing it from them.
+		resourceAttributes.Upsert(key, moved.value)
+		resourceValue, _ := resourceAttributes.Get(key)
+		for _, attributes := range recordsAttributes {
+			if value, found := attributes.Get(key); found && !value.Equal(resourceValue) {
+				removed++
+			}
+			attributes.Remove(key)
+		}
+	}
+	return removed
+}
@@ -0,0 +1,269 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestMoveAttributesRecordToResource(t *testing.T) {
+	testCases := []struct {
+		name             string
+		differingValues  string
+		overwrite        bool
+		resource         map[string]interface{}
+		records          []map[string]interface{}
+		expectedResource map[string]interface{}
+		expectedRecords  []map[string]interface{}
+		expectedRemoved  int64
+	}{
+		{
+			name:            "moves equal values",
+			differingValues: moveDifferingValuesSkip,
+			resource:        map[string]interface{}{},
+			records: []map[string]interface{}{
+				{"k8s.pod.name": "a", "message": "1"},
+				{"k8s.pod.name": "a", "message": "2"},
+				{"message": "3"},
+			},
+			expectedResource: map[string]interface{}{"k8s.pod.name": "a"},
+			expectedRecords: []map[string]interface{}{
+				{"message": "1"},
+				{"message": "2"},
+				{"message": "3"},
+			},
+		},
+		{
+			name:            "skips conflicting values",
+			differingValues: moveDifferingValuesSkip,
+			resource:        map[string]interface{}{},
+			records: []map[string]interface{}{
+				{"k8s.pod.name": "a", "k8s.node.name": "n"},
+				{"k8s.pod.name": "b", "k8s.node.name": "n"},
+			},
+			expectedResource: map[string]interface{}{"k8s.node.name": "n"},
+			expectedRecords: []map[string]interface{}{
+				{"k8s.pod.name": "a"},
+				{"k8s.pod.name": "b"},
+			},
+		},
+		{
+			name:            "first value on conflict",
+			differingValues: moveDifferingValuesFirst,
+			resource:        map[string]interface{}{},
+			records: []map[string]interface{}{
+				{"k8s.pod.name": "a"},
+				{"k8s.pod.name": "b"},
+			},
+			expectedResource: map[string]interface{}{"k8s.pod.name": "a"},
+			expectedRecords:  []map[string]interface{}{{}, {}},
+			expectedRemoved:  1,
+		},
+		{
+			name:            "last value on conflict",
+			differingValues: moveDifferingValuesLast,
+			resource:        map[string]interface{}{},
+			records: []map[string]interface{}{
+				{"k8s.pod.name": "a"},
+				{"k8s.pod.name": "b"},
+			},
+			expectedResource: map[string]interface{}{"k8s.pod.name": "b"},
+			expectedRecords:  []map[string]interface{}{{}, {}},
+			expectedRemoved:  1,
+		},
+		{
+			name:             "keeps existing resource attribute",
+			differingValues:  moveDifferingValuesSkip,
+			resource:         map[string]interface{}{"k8s.pod.name": "r"},
+			records:          []map[string]interface{}{{"k8s.pod.name": "a"}},
+			expectedResource: map[string]interface{}{"k8s.pod.name": "r"},
+			expectedRecords:  []map[string]interface{}{{"k8s.pod.name": "a"}},
+		},
+		{
+			name:             "overwrites existing resource attribute",
+			differingValues:  moveDifferingValuesSkip,
+			overwrite:        true,
+			resource:         map[string]interface{}{"k8s.pod.name": "r"},
+			records:          []map[string]interface{}{{"k8s.pod.name": "a"}},
+			expectedResource: map[string]interface{}{"k8s.pod.name": "a"},
+			expectedRecords:  []map[string]interface{}{{}},
+			expectedRemoved:  1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resetTelemetryViews(t)
+			processor, err := newMoveAttributesProcessor(&MoveAttributesConfig{
+				Enabled:         true,
+				Patterns:        []string{"k8s.*"},
+				Direction:       moveDirectionRecordToResource,
+				DifferingValues: testCase.differingValues,
+				Overwrite:       testCase.overwrite,
+			})
+			require.NoError(t, err)
+
+			logs := plog.NewLogs()
+			resourceLogs := logs.ResourceLogs().AppendEmpty()
+			pcommon.NewMapFromRaw(testCase.resource).CopyTo(resourceLogs.Resource().Attributes())
+			logRecords := resourceLogs.ScopeLogs().AppendEmpty().LogRecords()
+			for _, record := range testCase.records {
+				pcommon.NewMapFromRaw(record).CopyTo(logRecords.AppendEmpty().Attributes())
+			}
+
+			require.NoError(t, processor.ProcessLogs(logs))
+			assert.Equal(t, testCase.expectedRemoved, telemetryViewValues(t, viewAttributesRemoved)["move_attributes/logs"])
+
+			assert.Equal(t, testCase.expectedResource, resourceLogs.Resource().Attributes().AsRaw())
+			require.Equal(t, len(testCase.expectedRecords), logRecords.Len())
+			for i, expected := range testCase.expectedRecords {
+				assert.Equal(t, expected, logRecords.At(i).Attributes().AsRaw())
+			}
+		})
+	}
+}
+
+func TestMoveAttributesResourceToRecord(t *testing.T) {
+	testCases := []struct {
+		name            string
+		overwrite       bool
+		expected        []map[string]interface{}
+		expectedRemoved int64
+	}{
+		{
+			name: "keeps existing record attribute",
+			expected: []map[string]interface{}{
+				{"k8s.pod.name": "a"},
+				{"k8s.pod.name": "b"},
+			},
+		},
+		{
+			name:      "overwrites existing record attribute",
+			overwrite: true,
+			expected: []map[string]interface{}{
+				{"k8s.pod.name": "a"},
+				{"k8s.pod.name": "a"},
+			},
+			expectedRemoved: 1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resetTelemetryViews(t)
+			processor, err := newMoveAttributesProcessor(&MoveAttributesConfig{
+				Enabled:         true,
+				Patterns:        []string{"k8s.*"},
+				Direction:       moveDirectionResourceToRecord,
+				DifferingValues: moveDifferingValuesSkip,
+				Overwrite:       testCase.overwrite,
+			})
+			require.NoError(t, err)
+
+			traces := ptrace.NewTraces()
+			resourceSpans := traces.ResourceSpans().AppendEmpty()
+			resourceSpans.Resource().Attributes().InsertString("k8s.pod.name", "a")
+			resourceSpans.Resource().Attributes().InsertString("host", "h")
+			spans := resourceSpans.ScopeSpans().AppendEmpty().Spans()
+			spans.AppendEmpty()
+			spans.AppendEmpty().Attributes().InsertString("k8s.pod.name", "b")
+
+			emptyResourceSpans := traces.ResourceSpans().AppendEmpty()
+			emptyResourceSpans.Resource().Attributes().InsertString("k8s.pod.name", "c")
+
+			require.NoError(t, processor.ProcessTraces(traces))
+			assert.Equal(t, testCase.expectedRemoved, telemetryViewValues(t, viewAttributesRemoved)["move_attributes/traces"])
+
+			assert.Equal(t, map[string]interface{}{"host": "h"}, resourceSpans.Resource().Attributes().AsRaw())
+			for i, expected := range testCase.expected {
+				assert.Equal(t, expected, spans.At(i).Attributes().AsRaw())
+			}
+			assert.Equal(t, map[string]interface{}{"k8s.pod.name": "c"}, emptyResourceSpans.Resource().Attributes().AsRaw())
+		})
+	}
+}
+
+func TestMoveAttributesResourceToRecordCountsLostValue(t *testing.T) {
+	processor, err := newMoveAttributesProcessor(&MoveAttributesConfig{
+		Enabled:         true,
+		Patterns:        []string{"k8s.*"},
+		Direction:       moveDirectionResourceToRecord,
+		DifferingValues: moveDifferingValuesSkip,
+	})
+	require.NoError(t, err)
+
+	resourceAttributes := pcommon.NewMapFromRaw(map[string]interface{}{"k8s.pod.name": "a"})
+	recordsAttributes := []pcommon.Map{
+		pcommon.NewMapFromRaw(map[string]interface{}{"k8s.pod.name": "b"}),
+		pcommon.NewMapFromRaw(map[string]interface{}{"k8s.pod.name": "c"}),
+	}
+
+	// No record receives the resource value, so it is lost.
+	assert.Equal(t, 1, processor.moveAttributes(resourceAttributes, recordsAttributes))
+	assert.Equal(t, 0, resourceAttributes.Len())
+}
+
+func TestMoveAttributesMetrics(t *testing.T) {
+	processor, err := newMoveAttributesProcessor(&MoveAttributesConfig{
+		Enabled:         true,
+		Patterns:        []string{"host"},
+		Direction:       moveDirectionRecordToResource,
+		DifferingValues: moveDifferingValuesSkip,
+	})
+	require.NoError(t, err)
+
+	metrics := pmetric.NewMetrics()
+	resourceMetrics := metrics.ResourceMetrics().AppendEmpty()
+	metricsSlice := resourceMetrics.ScopeMetrics().AppendEmpty().Metrics()
+	gauge := metricsSlice.AppendEmpty()
+	gauge.SetDataType(pmetric.MetricDataTypeGauge)
+	gauge.Gauge().DataPoints().AppendEmpty().Attributes().InsertString("host", "h")
+	sum := metricsSlice.AppendEmpty()
+	sum.SetDataType(pmetric.MetricDataTypeSum)
+	sum.Sum().DataPoints().AppendEmpty().Attributes().InsertString("host", "h")
+
+	require.NoError(t, processor.ProcessMetrics(metrics))
+
+	assert.Equal(t, map[string]interface{}{"host": "h"}, resourceMetrics.Resource().Attributes().AsRaw())
+	assert.Equal(t, 0, gauge.Gauge().DataPoints().At(0).Attributes().Len())
+	assert.Equal(t, 0, sum.Sum().DataPoints().At(0).Attributes().Len())
+}
+
+func TestMoveAttributesInvalidConfig(t *testing.T) {
+	_, err := newMoveAttributesProcessor(&MoveAttributesConfig{Enabled: true, Direction: moveDirectionRecordToResource, DifferingValues: "merge"})
+	assert.EqualError(t, err, `invalid differing_values strategy: "merge"`)
+}
+
+func TestMoveAttributesCondition(t *testing.T) {
+	for _, direction := range []string{moveDirectionRecordToResource, moveDirectionResourceToRecord} {
+		config := createDefaultConfig().(*Config)
+		config.MoveAttributes.Enabled = true
+		config.MoveAttributes.Patterns = []string{"host"}
+		config.MoveAttributes.Direction = direction
+		config.Conditions = map[string]ConditionConfig{
+			"move_attributes": {Attribute: "source", Value: "k8s"},
+		}
+
+		_, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), config)
+		assert.EqualError(t, err, "conditions: move_attributes: sub-processors which move attributes between resources and records cannot have a condition")
+	}
+}
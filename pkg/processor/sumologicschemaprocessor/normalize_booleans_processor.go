@@ -0,0 +1,168 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// NormalizeBooleansConfig configures the normalize_booleans sub-processor.
+type NormalizeBooleansConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Patterns are attribute keys to normalize. The `*` character matches any sequence of characters.
+	Patterns []string `mapstructure:"patterns"`
+	// WildcardOptions change how Patterns are matched.
+	WildcardOptions `mapstructure:",squash"`
+	// TrueValues are the string values converted to true, compared case-insensitively.
+	// If empty, defaultNormalizeBooleansTrueValues are used.
+	TrueValues []string `mapstructure:"true_values"`
+	// FalseValues are the string values converted to false, compared case-insensitively.
+	// If empty, defaultNormalizeBooleansFalseValues are used.
+	FalseValues []string `mapstructure:"false_values"`
+}
+
+// The default values are not set in the default config, as a configured list would be merged into them
+// instead of replacing them.
+var (
+	defaultNormalizeBooleansTrueValues  = []string{"true", "1", "yes", "y", "on"}
+	defaultNormalizeBooleansFalseValues = []string{"false", "0", "no", "n", "off"}
+)
+
+// normalizeBooleansProcessor converts string attribute values representing booleans to bool values.
+type normalizeBooleansProcessor struct {
+	enabled      bool
+	regexes      []*regexp.Regexp
+	maxKeyLength int
+	// values maps lower case string values to the bool values they represent.
+	values map[string]bool
+}
+
+func newNormalizeBooleansProcessor(config *NormalizeBooleansConfig) (*normalizeBooleansProcessor, error) {
+	if config.Enabled {
+		if err := validateNormalizeBooleansConfig(config); err != nil {
+			return nil, err
+		}
+	}
+
+	regexes, err := compileWildcards(config.Patterns, config.WildcardOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	trueValues, falseValues := booleanValues(config)
+	values := make(map[string]bool, len(trueValues)+len(falseValues))
+	for _, value := range trueValues {
+		values[strings.ToLower(value)] = true
+	}
+	for _, value := range falseValues {
+		values[strings.ToLower(value)] = false
+	}
+
+	return &normalizeBooleansProcessor{
+		enabled: config.Enabled,
+		regexes: regexes,
+		values:  values,
+	}, nil
+}
+
+// booleanValues returns the configured true and false values, or the default ones if not configured.
+func booleanValues(config *NormalizeBooleansConfig) ([]string, []string) {
+	trueValues, falseValues := config.TrueValues, config.FalseValues
+	if len(trueValues) == 0 {
+		trueValues = defaultNormalizeBooleansTrueValues
+	}
+	if len(falseValues) == 0 {
+		falseValues = defaultNormalizeBooleansFalseValues
+	}
+	return trueValues, falseValues
+}
+
+func validateNormalizeBooleansConfig(config *NormalizeBooleansConfig) error {
+	trueValues, falseValues := booleanValues(config)
+
+	lowerTrueValues := make(map[string]struct{}, len(trueValues))
+	for _, value := range trueValues {
+		if value == "" {
+			return errors.New("true_values must not contain an empty value")
+		}
+		lowerTrueValues[strings.ToLower(value)] = struct{}{}
+	}
+
+	for _, value := range falseValues {
+		if value == "" {
+			return errors.New("false_values must not contain an empty value")
+		}
+		if _, ok := lowerTrueValues[strings.ToLower(value)]; ok {
+			return fmt.Errorf("value %q is in both true_values and false_values", value)
+		}
+	}
+
+	return nil
+}
+
+func (proc *normalizeBooleansProcessor) ProcessLogs(logs plog.Logs) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnLogs(proc, logs)
+	}
+	return nil
+}
+
+func (proc *normalizeBooleansProcessor) ProcessMetrics(metrics pmetric.Metrics) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnMetrics(proc, metrics)
+	}
+	return nil
+}
+
+func (proc *normalizeBooleansProcessor) ProcessTraces(traces ptrace.Traces) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnTraces(proc, traces)
+	}
+	return nil
+}
+
+func (proc *normalizeBooleansProcessor) IsEnabled() bool {
+	return proc.enabled
+}
+
+func (*normalizeBooleansProcessor) ConfigPropertyName() string {
+	return "normalize_booleans"
+}
+
+func (proc *normalizeBooleansProcessor) setMaxKeyLength(maxKeyLength int) {
+	proc.maxKeyLength = maxKeyLength
+}
+
+func (proc *normalizeBooleansProcessor) processAttributes(attributes pcommon.Map) int {
+	attributes.Range(func(key string, value pcommon.Value) bool {
+		if value.Type() != pcommon.ValueTypeString || !matchesAnyKeyRegex(proc.regexes, key, proc.maxKeyLength) {
+			return true
+		}
+
+		if boolVal, ok := proc.values[strings.ToLower(value.StringVal())]; ok {
+			value.SetBoolVal(boolVal)
+		}
+		return true
+	})
+	return 0
+}
@@ -0,0 +1,147 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestNormalizeBooleans(t *testing.T) {
+	config := createDefaultConfig().(*Config).NormalizeBooleans
+	config.Enabled = true
+	config.Patterns = []string{"*.enabled", "sampled"}
+
+	processor, err := newNormalizeBooleansProcessor(config)
+	require.NoError(t, err)
+
+	attributes := pcommon.NewMapFromRaw(map[string]interface{}{
+		"a.enabled": "true",
+		"b.enabled": "TRUE",
+		"c.enabled": "1",
+		"d.enabled": "Yes",
+		"e.enabled": "on",
+		"f.enabled": "False",
+		"g.enabled": "0",
+		"h.enabled": "no",
+		"i.enabled": "OFF",
+		"j.enabled": "maybe",
+		"k.enabled": int64(1),
+		"l.enabled": true,
+		"sampled":   "y",
+		"other":     "yes",
+	})
+	processor.processAttributes(attributes)
+
+	assert.Equal(t, map[string]interface{}{
+		"a.enabled": true,
+		"b.enabled": true,
+		"c.enabled": true,
+		"d.enabled": true,
+		"e.enabled": true,
+		"f.enabled": false,
+		"g.enabled": false,
+		"h.enabled": false,
+		"i.enabled": false,
+		"j.enabled": "maybe",
+		"k.enabled": int64(1),
+		"l.enabled": true,
+		"sampled":   true,
+		"other":     "yes",
+	}, attributes.AsRaw())
+}
+
+func TestNormalizeBooleansCustomValues(t *testing.T) {
+	processor, err := newNormalizeBooleansProcessor(&NormalizeBooleansConfig{
+		Enabled:     true,
+		Patterns:    []string{"*"},
+		TrueValues:  []string{"ja"},
+		FalseValues: []string{"nein"},
+	})
+	require.NoError(t, err)
+
+	attributes := pcommon.NewMapFromRaw(map[string]interface{}{"a": "JA", "b": "nein", "c": "true"})
+	processor.processAttributes(attributes)
+
+	assert.Equal(t, map[string]interface{}{"a": true, "b": false, "c": "true"}, attributes.AsRaw())
+}
+
+func TestNormalizeBooleansDefaultValues(t *testing.T) {
+	processor, err := newNormalizeBooleansProcessor(&NormalizeBooleansConfig{
+		Enabled:    true,
+		Patterns:   []string{"*"},
+		TrueValues: []string{"ja"},
+	})
+	require.NoError(t, err)
+
+	attributes := pcommon.NewMapFromRaw(map[string]interface{}{"a": "ja", "b": "off", "c": "yes"})
+	processor.processAttributes(attributes)
+
+	assert.Equal(t, map[string]interface{}{"a": true, "b": false, "c": "yes"}, attributes.AsRaw())
+
+	_, err = newNormalizeBooleansProcessor(&NormalizeBooleansConfig{Enabled: true, TrueValues: []string{"no"}})
+	assert.EqualError(t, err, `value "no" is in both true_values and false_values`)
+}
+
+func TestNormalizeBooleansAllSignals(t *testing.T) {
+	processor, err := newNormalizeBooleansProcessor(&NormalizeBooleansConfig{
+		Enabled:     true,
+		Patterns:    []string{"sampled"},
+		TrueValues:  []string{"yes"},
+		FalseValues: []string{"no"},
+	})
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	resourceLogs.Resource().Attributes().InsertString("sampled", "yes")
+	logRecord := resourceLogs.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	logRecord.Attributes().InsertString("sampled", "no")
+	require.NoError(t, processor.ProcessLogs(logs))
+	assert.Equal(t, map[string]interface{}{"sampled": true}, resourceLogs.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"sampled": false}, logRecord.Attributes().AsRaw())
+
+	metrics := pmetric.NewMetrics()
+	metric := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetDataType(pmetric.MetricDataTypeSum)
+	dataPoint := metric.Sum().DataPoints().AppendEmpty()
+	dataPoint.Attributes().InsertString("sampled", "yes")
+	require.NoError(t, processor.ProcessMetrics(metrics))
+	assert.Equal(t, map[string]interface{}{"sampled": true}, dataPoint.Attributes().AsRaw())
+
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.Attributes().InsertString("sampled", "no")
+	require.NoError(t, processor.ProcessTraces(traces))
+	assert.Equal(t, map[string]interface{}{"sampled": false}, span.Attributes().AsRaw())
+}
+
+func TestNormalizeBooleansInvalidConfig(t *testing.T) {
+	_, err := newNormalizeBooleansProcessor(&NormalizeBooleansConfig{
+		Enabled:     true,
+		TrueValues:  []string{"yes", "1"},
+		FalseValues: []string{"no", "YES"},
+	})
+	assert.EqualError(t, err, `value "YES" is in both true_values and false_values`)
+
+	_, err = newNormalizeBooleansProcessor(&NormalizeBooleansConfig{Enabled: true, TrueValues: []string{""}})
+	assert.EqualError(t, err, "true_values must not contain an empty value")
+}
@@ -0,0 +1,129 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	normalizeKeysCaseLower = "lower"
+	normalizeKeysCaseUpper = "upper"
+)
+
+// NormalizeKeysConfig configures the normalize_keys sub-processor.
+type NormalizeKeysConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Case is either `lower` or `upper`.
+	Case string `mapstructure:"case"`
+	// Overwrite defines whether an attribute which already has the normalized key should be overwritten.
+	Overwrite bool `mapstructure:"overwrite"`
+}
+
+// normalizeKeysProcessor changes the case of all attribute keys.
+type normalizeKeysProcessor struct {
+	enabled   bool
+	normalize func(string) string
+	overwrite bool
+}
+
+func newNormalizeKeysProcessor(config *NormalizeKeysConfig) (*normalizeKeysProcessor, error) {
+	if err := validateNormalizeKeysConfig(config); err != nil {
+		return nil, err
+	}
+
+	normalize := strings.ToLower
+	if config.Case == normalizeKeysCaseUpper {
+		normalize = strings.ToUpper
+	}
+
+	return &normalizeKeysProcessor{
+		enabled:   config.Enabled,
+		normalize: normalize,
+		overwrite: config.Overwrite,
+	}, nil
+}
+
+func validateNormalizeKeysConfig(config *NormalizeKeysConfig) error {
+	switch config.Case {
+	case normalizeKeysCaseLower, normalizeKeysCaseUpper:
+	default:
+		return fmt.Errorf("invalid case: %q", config.Case)
+	}
+
+	return nil
+}
+
+func (proc *normalizeKeysProcessor) ProcessLogs(logs plog.Logs) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnLogs(proc, logs)
+	}
+	return nil
+}
+
+func (proc *normalizeKeysProcessor) ProcessMetrics(metrics pmetric.Metrics) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnMetrics(proc, metrics)
+	}
+	return nil
+}
+
+func (proc *normalizeKeysProcessor) ProcessTraces(traces ptrace.Traces) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnTraces(proc, traces)
+	}
+	return nil
+}
+
+func (proc *normalizeKeysProcessor) IsEnabled() bool {
+	return proc.enabled
+}
+
+func (*normalizeKeysProcessor) ConfigPropertyName() string {
+	return "normalize_keys"
+}
+
+func (proc *normalizeKeysProcessor) processAttributes(attributes pcommon.Map) int {
+	keys := make([]string, 0, attributes.Len())
+	attributes.Range(func(key string, _ pcommon.Value) bool {
+		if proc.normalize(key) != key {
+			keys = append(keys, key)
+		}
+		return true
+	})
+
+	removed := 0
+	for _, key := range keys {
+		newKey := proc.normalize(key)
+		_, exists := attributes.Get(newKey)
+		if exists && !proc.overwrite {
+			continue
+		}
+		if exists {
+			removed++
+		}
+
+		value, _ := attributes.Get(key)
+		attributes.Upsert(newKey, value)
+		attributes.Remove(key)
+	}
+	return removed
+}
@@ -0,0 +1,105 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestNormalizeKeys(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   NormalizeKeysConfig
+		input    [][2]string
+		expected map[string]interface{}
+	}{
+		{
+			name:   "lowercases keys",
+			config: NormalizeKeysConfig{Case: "lower"},
+			input: [][2]string{
+				{"Pod_Name", "pod"},
+				{"host", "host"},
+				{"PORT", "80"},
+			},
+			expected: map[string]interface{}{
+				"pod_name": "pod",
+				"host":     "host",
+				"port":     "80",
+			},
+		},
+		{
+			name:   "uppercases keys",
+			config: NormalizeKeysConfig{Case: "upper"},
+			input: [][2]string{
+				{"Pod_Name", "pod"},
+			},
+			expected: map[string]interface{}{
+				"POD_NAME": "pod",
+			},
+		},
+		{
+			name:   "skips colliding keys",
+			config: NormalizeKeysConfig{Case: "lower"},
+			input: [][2]string{
+				{"pod_name", "first"},
+				{"Pod_Name", "second"},
+				{"POD_NAME", "third"},
+			},
+			expected: map[string]interface{}{
+				"pod_name": "first",
+				"Pod_Name": "second",
+				"POD_NAME": "third",
+			},
+		},
+		{
+			name:   "overwrites colliding keys",
+			config: NormalizeKeysConfig{Case: "lower", Overwrite: true},
+			input: [][2]string{
+				{"pod_name", "first"},
+				{"Pod_Name", "second"},
+				{"POD_NAME", "third"},
+			},
+			expected: map[string]interface{}{
+				"pod_name": "third",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.config.Enabled = true
+			processor, err := newNormalizeKeysProcessor(&testCase.config)
+			require.NoError(t, err)
+
+			// Insert in a fixed order, as the conflict resolution depends on it.
+			attributes := pcommon.NewMap()
+			for _, keyValue := range testCase.input {
+				attributes.InsertString(keyValue[0], keyValue[1])
+			}
+			processor.processAttributes(attributes)
+
+			assert.Equal(t, testCase.expected, attributes.AsRaw())
+		})
+	}
+}
+
+func TestNormalizeKeysInvalidConfig(t *testing.T) {
+	_, err := newNormalizeKeysProcessor(&NormalizeKeysConfig{Case: "title"})
+	assert.EqualError(t, err, `invalid case: "title"`)
+}
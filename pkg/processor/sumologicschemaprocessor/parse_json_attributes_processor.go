@@ -0,0 +1,154 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// ParseJSONAttributesConfig configures the parse_json_attributes sub-processor.
+type ParseJSONAttributesConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Attribute is the key of the attribute containing a JSON object string.
+	Attribute string `mapstructure:"attribute"`
+	// Target is the key of the attribute the parsed object is put under.
+	// If empty, the keys of the parsed object are merged into the attributes.
+	Target string `mapstructure:"target"`
+}
+
+// parseJSONAttributesProcessor parses a JSON object string attribute into structured attributes.
+type parseJSONAttributesProcessor struct {
+	enabled   bool
+	attribute string
+	target    string
+}
+
+func newParseJSONAttributesProcessor(config *ParseJSONAttributesConfig) (*parseJSONAttributesProcessor, error) {
+	if config.Enabled {
+		if err := validateParseJSONAttributesConfig(config); err != nil {
+			return nil, err
+		}
+	}
+
+	return &parseJSONAttributesProcessor{
+		enabled:   config.Enabled,
+		attribute: config.Attribute,
+		target:    config.Target,
+	}, nil
+}
+
+func validateParseJSONAttributesConfig(config *ParseJSONAttributesConfig) error {
+	if config.Attribute == "" {
+		return errors.New("attribute must not be empty")
+	}
+	return nil
+}
+
+func (proc *parseJSONAttributesProcessor) ProcessLogs(logs plog.Logs) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnLogs(proc, logs)
+	}
+	return nil
+}
+
+func (proc *parseJSONAttributesProcessor) ProcessMetrics(metrics pmetric.Metrics) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnMetrics(proc, metrics)
+	}
+	return nil
+}
+
+func (proc *parseJSONAttributesProcessor) ProcessTraces(traces ptrace.Traces) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnTraces(proc, traces)
+	}
+	return nil
+}
+
+func (proc *parseJSONAttributesProcessor) IsEnabled() bool {
+	return proc.enabled
+}
+
+func (*parseJSONAttributesProcessor) ConfigPropertyName() string {
+	return "parse_json_attributes"
+}
+
+func (proc *parseJSONAttributesProcessor) processAttributes(attributes pcommon.Map) int {
+	value, found := attributes.Get(proc.attribute)
+	if !found || value.Type() != pcommon.ValueTypeString {
+		return 0
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(value.StringVal()))
+	decoder.UseNumber()
+
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil || object == nil || decoder.More() {
+		return 0
+	}
+	parsed := pcommon.NewMapFromRaw(convertJSONNumbers(object).(map[string]interface{}))
+
+	if proc.target != "" {
+		parsedValue := pcommon.NewValueMap()
+		parsed.CopyTo(parsedValue.MapVal())
+
+		// The parsed attribute is replaced by the target, which may overwrite an existing attribute too.
+		removed := 1
+		attributes.Remove(proc.attribute)
+		if _, exists := attributes.Get(proc.target); exists {
+			removed++
+		}
+		attributes.Upsert(proc.target, parsedValue)
+		return removed
+	}
+
+	attributes.Remove(proc.attribute)
+	parsed.Range(func(key string, value pcommon.Value) bool {
+		attributes.Insert(key, value)
+		return true
+	})
+	return 1
+}
+
+// convertJSONNumbers replaces json.Number values with int64 or float64 values.
+func convertJSONNumbers(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case json.Number:
+		if intVal, err := typed.Int64(); err == nil {
+			return intVal
+		}
+		floatVal, _ := typed.Float64()
+		return floatVal
+	case map[string]interface{}:
+		for key, item := range typed {
+			typed[key] = convertJSONNumbers(item)
+		}
+		return typed
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = convertJSONNumbers(item)
+		}
+		return typed
+	default:
+		return value
+	}
+}
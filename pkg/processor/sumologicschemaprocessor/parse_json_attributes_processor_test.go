@@ -0,0 +1,115 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestParseJSONAttributes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		target   string
+		input    map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name: "merges parsed keys into attributes",
+			input: map[string]interface{}{
+				"payload": `{"user": "john", "age": 42, "score": 1.5, "admin": false}`,
+				"user":    "existing",
+			},
+			expected: map[string]interface{}{
+				"user":  "existing",
+				"age":   int64(42),
+				"score": 1.5,
+				"admin": false,
+			},
+		},
+		{
+			name:   "nests parsed object under target",
+			target: "parsed",
+			input: map[string]interface{}{
+				"payload": `{"user": "john"}`,
+			},
+			expected: map[string]interface{}{
+				"parsed": map[string]interface{}{"user": "john"},
+			},
+		},
+		{
+			name:   "parses nested objects and arrays",
+			target: "payload",
+			input: map[string]interface{}{
+				"payload": `{"http": {"status": 200, "headers": ["a", "b"]}, "tags": [1, {"k": null}]}`,
+			},
+			expected: map[string]interface{}{
+				"payload": map[string]interface{}{
+					"http": map[string]interface{}{
+						"status":  int64(200),
+						"headers": []interface{}{"a", "b"},
+					},
+					"tags": []interface{}{int64(1), map[string]interface{}{"k": nil}},
+				},
+			},
+		},
+		{
+			name: "leaves invalid JSON untouched",
+			input: map[string]interface{}{
+				"payload": `{"user": `,
+			},
+			expected: map[string]interface{}{
+				"payload": `{"user": `,
+			},
+		},
+		{
+			name: "leaves JSON which is not an object untouched",
+			input: map[string]interface{}{
+				"payload": `["a", "b"]`,
+			},
+			expected: map[string]interface{}{
+				"payload": `["a", "b"]`,
+			},
+		},
+		{
+			name: "leaves trailing data untouched",
+			input: map[string]interface{}{
+				"payload": `{"a": 1} {"b": 2}`,
+			},
+			expected: map[string]interface{}{
+				"payload": `{"a": 1} {"b": 2}`,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			processor, err := newParseJSONAttributesProcessor(&ParseJSONAttributesConfig{
+				Enabled:   true,
+				Attribute: "payload",
+				Target:    testCase.target,
+			})
+			require.NoError(t, err)
+
+			attributes := pcommon.NewMapFromRaw(testCase.input)
+			processor.processAttributes(attributes)
+
+			assert.Equal(t, testCase.expected, attributes.AsRaw())
+		})
+	}
+}
@@ -17,78 +17,415 @@ package sumologicschemaprocessor
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
-type sumologicSchemaSubprocessor interface {
-	processLogs(plog.Logs) error
-	processMetrics(pmetric.Metrics) error
-	processTraces(ptrace.Traces) error
-	isEnabled() bool
-	ConfigPropertyName() string
+// subprocessorStarter is implemented by sub-processors which need to do some work when the processor starts,
+// for example load external data.
+type subprocessorStarter interface {
+	Start(context.Context, component.Host) error
+}
+
+// subprocessorShutdowner is implemented by sub-processors which need to release resources when the processor shuts down.
+type subprocessorShutdowner interface {
+	Shutdown(context.Context) error
 }
 
 type sumologicSchemaProcessor struct {
 	logger        *zap.Logger
-	subprocessors []sumologicSchemaSubprocessor
+	subprocessors []SubProcessor
+	// enabledSubprocessors are the enabled sub-processors, in the order they are run in.
+	enabledSubprocessors []SubProcessor
+	// steps are the enabled sub-processors with consecutive attributes sub-processors grouped,
+	// so that the data is traversed once for all of them.
+	steps []SubProcessor
+	// dryRun defines whether changes should only be logged instead of applied.
+	dryRun bool
+	// signals are the signals which are processed, others are passed through.
+	signals map[string]bool
 }
 
 func newSumologicSchemaProcessor(set component.ProcessorCreateSettings, config *Config) (*sumologicSchemaProcessor, error) {
-	cloudNamespaceProcessor, err := newCloudNamespaceProcessor(config.AddCloudNamespace)
+	processors, err := newSubprocessors(set, config)
+	if err != nil {
+		return nil, err
+	}
+
+	enabledProcessors := make([]SubProcessor, 0, len(processors))
+	for _, subprocessor := range processors {
+		if subprocessor.IsEnabled() {
+			enabledProcessors = append(enabledProcessors, subprocessor)
+		}
+	}
+
+	processor := &sumologicSchemaProcessor{
+		logger:               set.Logger,
+		subprocessors:        processors,
+		enabledSubprocessors: enabledProcessors,
+		steps:                groupAttributesSubprocessors(enabledProcessors, newProcessorTelemetry(config.RecordMatchDuration), config.IncludeExemplars),
+		dryRun:               config.DryRun,
+		signals:              processedSignals(config.Signals),
+	}
+
+	return processor, nil
+}
+
+// processedSignals returns the set of signals to process, all of them if none are configured.
+func processedSignals(signals []string) map[string]bool {
+	if len(signals) == 0 {
+		signals = []string{signalLogs, signalMetrics, signalTraces}
+	}
+
+	processed := make(map[string]bool, len(signals))
+	for _, signal := range signals {
+		processed[signal] = true
+	}
+	return processed
+}
+
+// newSubprocessors creates all sub-processors, including disabled ones, in the order they should be run in.
+func newSubprocessors(set component.ProcessorCreateSettings, config *Config) ([]SubProcessor, error) {
+	cloudNamespaceProcessor, err := newCloudNamespaceProcessor(config.AddCloudNamespace, config.CloudNamespaceAttribute, config.CloudNamespaceMappings)
+	if err != nil {
+		return nil, err
+	}
+
+	translateAttributesProcessor, err := newTranslateAttributesProcessor(config.TranslateAttributes, config.TranslateAttributesExtra, config.TranslateAttributesDirection, config.TranslateAttributesSkip, config.TranslateAttributesScope, newMappingsFile(config.TranslateAttributesMappingsFile, config.TranslateAttributesWatch, set.Logger), set.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	translateTelegrafMetricsProcessor, err := newTranslateTelegrafMetricsProcessor(config.TranslateTelegrafAttributes, config.TranslateTelegrafExtra)
+	if err != nil {
+		return nil, err
+	}
+
+	translateMetricNamesProcessor, err := newTranslateMetricNamesProcessor(config.TranslateMetricNames)
+	if err != nil {
+		return nil, err
+	}
+
+	promoteBodyToAttributesProcessor, err := newPromoteBodyToAttributesProcessor(config.PromoteBodyToAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	sampleByAttributeProcessor, err := newSampleByAttributeProcessor(config.SampleByAttribute)
+	if err != nil {
+		return nil, err
+	}
+
+	mapSeverityProcessor, err := newMapSeverityProcessor(config.MapSeverity)
+	if err != nil {
+		return nil, err
+	}
+
+	setTimestampFromAttributeProcessor, err := newSetTimestampFromAttributeProcessor(config.SetTimestampFromAttribute, set.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	redactAttributesProcessor, err := newRedactAttributesProcessor(config.RedactAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	renameAttributesProcessor, err := newRenameAttributesProcessor(config.RenameAttributes, set.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	copyAttributesProcessor, err := newCopyAttributesProcessor(config.CopyAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	templateAttributesProcessor, err := newTemplateAttributesProcessor(config.TemplateAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	dropAttributesProcessor, err := newDropAttributesProcessor(config.DropAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizeKeysProcessor, err := newNormalizeKeysProcessor(config.NormalizeKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	rewriteKeysProcessor, err := newRewriteKeysProcessor(config.RewriteKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	coerceAttributesProcessor, err := newCoerceAttributesProcessor(config.CoerceAttributes, set.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizeBooleansProcessor, err := newNormalizeBooleansProcessor(config.NormalizeBooleans)
+	if err != nil {
+		return nil, err
+	}
+
+	splitAttributesProcessor, err := newSplitAttributesProcessor(config.SplitAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	trimAttributesProcessor, err := newTrimAttributesProcessor(config.TrimAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	limitAttributeLengthProcessor, err := newLimitAttributeLengthProcessor(config.LimitAttributeLength)
+	if err != nil {
+		return nil, err
+	}
+
+	prefixAttributesProcessor, err := newPrefixAttributesProcessor(config.PrefixAttributes)
 	if err != nil {
 		return nil, err
 	}
 
-	translateAttributesProcessor, err := newTranslateAttributesProcessor(config.TranslateAttributes)
+	suffixAttributesProcessor, err := newSuffixAttributesProcessor(config.SuffixAttributes)
 	if err != nil {
 		return nil, err
 	}
 
-	translateTelegrafMetricsProcessor, err := newTranslateTelegrafMetricsProcessor(config.TranslateTelegrafAttributes)
+	maxCardinalityProcessor, err := newMaxCardinalityProcessor(config.MaxCardinality)
 	if err != nil {
 		return nil, err
 	}
 
-	processors := []sumologicSchemaSubprocessor{
+	defaultAttributesProcessor, err := newDefaultAttributesProcessor(config.DefaultAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	moveAttributesProcessor, err := newMoveAttributesProcessor(config.MoveAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	dedupeAttributesProcessor, err := newDedupeAttributesProcessor(config.DedupeAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	parseJSONAttributesProcessor, err := newParseJSONAttributesProcessor(config.ParseJSONAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	processors := []SubProcessor{
 		cloudNamespaceProcessor,
 		translateAttributesProcessor,
 		translateTelegrafMetricsProcessor,
+		translateMetricNamesProcessor,
+		promoteBodyToAttributesProcessor,
+		sampleByAttributeProcessor,
+		mapSeverityProcessor,
+		setTimestampFromAttributeProcessor,
+		redactAttributesProcessor,
+		renameAttributesProcessor,
+		copyAttributesProcessor,
+		templateAttributesProcessor,
+		dropAttributesProcessor,
+		normalizeKeysProcessor,
+		rewriteKeysProcessor,
+		coerceAttributesProcessor,
+		normalizeBooleansProcessor,
+		splitAttributesProcessor,
+		trimAttributesProcessor,
+		limitAttributeLengthProcessor,
+		prefixAttributesProcessor,
+		suffixAttributesProcessor,
+		maxCardinalityProcessor,
+		defaultAttributesProcessor,
+		moveAttributesProcessor,
+		dedupeAttributesProcessor,
+		parseJSONAttributesProcessor,
 	}
 
-	processor := &sumologicSchemaProcessor{
-		logger:        set.Logger,
-		subprocessors: processors,
+	for _, subprocessor := range processors {
+		if matcher, ok := subprocessor.(keyMatchingSubprocessor); ok {
+			matcher.setMaxKeyLength(config.MaxKeyLength)
+		}
 	}
 
-	return processor, nil
+	customProcessors, err := newCustomSubprocessors(config.CustomSubprocessors)
+	if err != nil {
+		return nil, err
+	}
+	for _, customProcessor := range customProcessors {
+		for _, subprocessor := range processors {
+			if subprocessor.ConfigPropertyName() == customProcessor.ConfigPropertyName() {
+				return nil, fmt.Errorf("custom_subprocessors: %q is the name of a built-in sub-processor", customProcessor.ConfigPropertyName())
+			}
+		}
+	}
+	processors = append(processors, customProcessors...)
+
+	processors, err = wrapConditionalSubprocessors(processors, config.Conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	processors, err = orderSubprocessors(processors, config.ProcessorOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	// The key length guard is not part of the order, so that huge keys are handled before any pattern is matched.
+	maxKeyLengthGuard, err := newMaxKeyLengthGuard(config.MaxKeyLength, config.MaxKeyLengthAction, set.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]SubProcessor{maxKeyLengthGuard}, processors...), nil
 }
 
-func (processor *sumologicSchemaProcessor) start(_ context.Context, host component.Host) error {
-	procs := processor.subprocessors
-	processor.logger.Info(
-		"Processor sumologic_schema has started.",
-		zap.Bool(procs[0].ConfigPropertyName(), procs[0].isEnabled()),
-		zap.Bool(procs[1].ConfigPropertyName(), procs[1].isEnabled()),
-		zap.Bool(procs[2].ConfigPropertyName(), procs[2].isEnabled()),
-	)
+// wrapConditionalSubprocessors wraps the sub-processors which have a condition configured.
+func wrapConditionalSubprocessors(subprocessors []SubProcessor, conditions map[string]ConditionConfig) ([]SubProcessor, error) {
+	unused := make(map[string]struct{}, len(conditions))
+	for name := range conditions {
+		unused[name] = struct{}{}
+	}
+
+	for i, subprocessor := range subprocessors {
+		conditionConfig, ok := conditions[subprocessor.ConfigPropertyName()]
+		if !ok {
+			continue
+		}
+		delete(unused, subprocessor.ConfigPropertyName())
+
+		if _, ok := subprocessor.(recordsRemovingSubprocessor); ok {
+			return nil, fmt.Errorf("conditions: %s: sub-processors which remove records cannot have a condition", subprocessor.ConfigPropertyName())
+		}
+		if _, ok := subprocessor.(metricsChangingSubprocessor); ok {
+			return nil, fmt.Errorf("conditions: %s: sub-processors which change metrics cannot have a condition", subprocessor.ConfigPropertyName())
+		}
+		if _, ok := subprocessor.(resourceWritingSubprocessor); ok {
+			return nil, fmt.Errorf("conditions: %s: sub-processors which move attributes between resources and records cannot have a condition", subprocessor.ConfigPropertyName())
+		}
+
+		condition, err := newAttributeCondition(conditionConfig)
+		if err != nil {
+			return nil, fmt.Errorf("conditions: %s: %w", subprocessor.ConfigPropertyName(), err)
+		}
+		subprocessors[i] = newConditionalSubprocessor(subprocessor, condition)
+	}
+
+	if len(unused) > 0 {
+		names := make([]string, 0, len(unused))
+		for name := range unused {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("conditions: unknown sub-processor %q", names[0])
+	}
+
+	return subprocessors, nil
+}
+
+// orderSubprocessors returns the sub-processors in the given order of their configuration names.
+// If the order is empty, the sub-processors are returned unchanged.
+// Every enabled sub-processor has to appear in the order exactly once, disabled sub-processors may be omitted.
+func orderSubprocessors(subprocessors []SubProcessor, order []string) ([]SubProcessor, error) {
+	if len(order) == 0 {
+		return subprocessors, nil
+	}
+
+	byName := make(map[string]SubProcessor, len(subprocessors))
+	for _, subprocessor := range subprocessors {
+		byName[subprocessor.ConfigPropertyName()] = subprocessor
+	}
+
+	ordered := make([]SubProcessor, 0, len(order))
+	for _, name := range order {
+		subprocessor, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("processor_order: unknown or duplicate sub-processor %q", name)
+		}
+		delete(byName, name)
+		ordered = append(ordered, subprocessor)
+	}
+
+	for _, subprocessor := range subprocessors {
+		if _, missing := byName[subprocessor.ConfigPropertyName()]; missing && subprocessor.IsEnabled() {
+			return nil, fmt.Errorf("processor_order: enabled sub-processor %q is missing", subprocessor.ConfigPropertyName())
+		}
+	}
+
+	return ordered, nil
+}
+
+func (processor *sumologicSchemaProcessor) start(ctx context.Context, host component.Host) error {
+	fields := make([]zap.Field, 0, len(processor.subprocessors))
+	for _, subprocessor := range processor.subprocessors {
+		fields = append(fields, zap.Bool(subprocessor.ConfigPropertyName(), subprocessor.IsEnabled()))
+	}
+
+	for _, subprocessor := range processor.enabledSubprocessors {
+		if starter, ok := subprocessor.(subprocessorStarter); ok {
+			if err := starter.Start(ctx, host); err != nil {
+				return fmt.Errorf("failed to start property %s: %w", subprocessor.ConfigPropertyName(), err)
+			}
+		}
+	}
+
+	if !processor.enabled() {
+		processor.logger.Info("Processor sumologic_schema has no enabled sub-processors, data is passed through unchanged.")
+	}
+
+	processor.logger.Info("Processor sumologic_schema has started.", fields...)
 	return nil
 }
 
-func (processor *sumologicSchemaProcessor) shutdown(_ context.Context) error {
+func (processor *sumologicSchemaProcessor) shutdown(ctx context.Context) error {
+	var errs error
+	for _, subprocessor := range processor.enabledSubprocessors {
+		if shutdowner, ok := subprocessor.(subprocessorShutdowner); ok {
+			if err := shutdowner.Shutdown(ctx); err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("failed to shut down property %s: %w", subprocessor.ConfigPropertyName(), err))
+			}
+		}
+	}
+
 	processor.logger.Info("Processor sumologic_schema has shut down.")
-	return nil
+	return errs
+}
+
+// enabled returns whether any sub-processor is enabled.
+// When none is, data is passed through without being traversed.
+func (processor *sumologicSchemaProcessor) enabled() bool {
+	return len(processor.enabledSubprocessors) > 0
 }
 
 func (processor *sumologicSchemaProcessor) processLogs(_ context.Context, logs plog.Logs) (plog.Logs, error) {
-	for i := 0; i < len(processor.subprocessors); i++ {
-		subprocessor := processor.subprocessors[i]
-		if err := subprocessor.processLogs(logs); err != nil {
-			return logs, fmt.Errorf("failed to process logs for property %s: %v", subprocessor.ConfigPropertyName(), err)
+	if !processor.enabled() || !processor.signals[signalLogs] {
+		return logs, nil
+	}
+
+	if processor.dryRun {
+		return logs, processor.dryRunLogs(logs)
+	}
+
+	for i := 0; i < len(processor.steps); i++ {
+		subprocessor := processor.steps[i]
+		if err := subprocessor.ProcessLogs(logs); err != nil {
+			return logs, fmt.Errorf("failed to process logs for property %s: %w", subprocessor.ConfigPropertyName(), err)
 		}
 	}
 
@@ -96,21 +433,65 @@ func (processor *sumologicSchemaProcessor) processLogs(_ context.Context, logs p
 }
 
 func (processor *sumologicSchemaProcessor) processMetrics(ctx context.Context, metrics pmetric.Metrics) (pmetric.Metrics, error) {
-	for i := 0; i < len(processor.subprocessors); i++ {
-		subprocessor := processor.subprocessors[i]
-		if err := subprocessor.processMetrics(metrics); err != nil {
-			return metrics, fmt.Errorf("failed to process metrics for property %s: %v", subprocessor.ConfigPropertyName(), err)
+	if !processor.enabled() || !processor.signals[signalMetrics] {
+		return metrics, nil
+	}
+
+	processor.logUnsupportedMetrics(metrics)
+
+	if processor.dryRun {
+		return metrics, processor.dryRunMetrics(metrics)
+	}
+
+	for i := 0; i < len(processor.steps); i++ {
+		subprocessor := processor.steps[i]
+		if err := subprocessor.ProcessMetrics(metrics); err != nil {
+			return metrics, fmt.Errorf("failed to process metrics for property %s: %w", subprocessor.ConfigPropertyName(), err)
 		}
 	}
 
 	return metrics, nil
 }
 
+// logUnsupportedMetrics logs metrics of data types whose data points can't be processed.
+// The metrics are only traversed when debug logging is enabled.
+func (processor *sumologicSchemaProcessor) logUnsupportedMetrics(metrics pmetric.Metrics) {
+	if !processor.logger.Core().Enabled(zapcore.DebugLevel) {
+		return
+	}
+
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		scopeMetricsSlice := metrics.ResourceMetrics().At(i).ScopeMetrics()
+
+		for j := 0; j < scopeMetricsSlice.Len(); j++ {
+			metricsSlice := scopeMetricsSlice.At(j).Metrics()
+
+			for k := 0; k < metricsSlice.Len(); k++ {
+				metric := metricsSlice.At(k)
+				if _, supported := dataPointsAttributes(metric); !supported {
+					processor.logger.Debug("Unsupported metric data type, data points are not processed",
+						zap.String("metric", metric.Name()),
+						zap.Int32("data_type", int32(metric.DataType())),
+					)
+				}
+			}
+		}
+	}
+}
+
 func (processor *sumologicSchemaProcessor) processTraces(ctx context.Context, traces ptrace.Traces) (ptrace.Traces, error) {
-	for i := 0; i < len(processor.subprocessors); i++ {
-		subprocessor := processor.subprocessors[i]
-		if err := subprocessor.processTraces(traces); err != nil {
-			return traces, fmt.Errorf("failed to process traces for property %s: %v", subprocessor.ConfigPropertyName(), err)
+	if !processor.enabled() || !processor.signals[signalTraces] {
+		return traces, nil
+	}
+
+	if processor.dryRun {
+		return traces, processor.dryRunTraces(traces)
+	}
+
+	for i := 0; i < len(processor.steps); i++ {
+		subprocessor := processor.steps[i]
+		if err := subprocessor.ProcessTraces(traces); err != nil {
+			return traces, fmt.Errorf("failed to process traces for property %s: %w", subprocessor.ConfigPropertyName(), err)
 		}
 	}
 
@@ -16,11 +16,16 @@ package sumologicschemaprocessor
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -83,7 +88,7 @@ func TestAddCloudNamespaceForLogs(t *testing.T) {
 				inputLogs := plog.NewLogs()
 				inputLogs.ResourceLogs().AppendEmpty().Resource().Attributes().InsertString("cloud.platform", "aws_eks")
 				inputLogs.ResourceLogs().AppendEmpty().Resource().Attributes().InsertString("cloud.platform", "aws_lambda")
-				inputLogs.ResourceLogs().AppendEmpty().Resource().Attributes().InsertString("cloud.platform", "azure_vm")
+				inputLogs.ResourceLogs().AppendEmpty().Resource().Attributes().InsertString("cloud.platform", "azure_aks")
 				inputLogs.ResourceLogs().AppendEmpty().Resource().Attributes().InsertString("cloud.platform", "gcp_app_engine")
 				return inputLogs
 			},
@@ -103,9 +108,8 @@ func TestAddCloudNamespaceForLogs(t *testing.T) {
 				return inputLogs
 			},
 			test: func(outputLogs plog.Logs) {
-				cloudNamespaceAttribute, found := outputLogs.ResourceLogs().At(0).Resource().Attributes().Get("cloud.namespace")
-				assert.True(t, found)
-				assert.Equal(t, "aws/ec2", cloudNamespaceAttribute.StringVal())
+				_, found := outputLogs.ResourceLogs().At(0).Resource().Attributes().Get("cloud.namespace")
+				assert.False(t, found)
 			},
 		},
 		{
@@ -210,7 +214,7 @@ func TestAddCloudNamespaceForMetrics(t *testing.T) {
 				inputMetrics := pmetric.NewMetrics()
 				inputMetrics.ResourceMetrics().AppendEmpty().Resource().Attributes().InsertString("cloud.platform", "aws_eks")
 				inputMetrics.ResourceMetrics().AppendEmpty().Resource().Attributes().InsertString("cloud.platform", "aws_lambda")
-				inputMetrics.ResourceMetrics().AppendEmpty().Resource().Attributes().InsertString("cloud.platform", "azure_vm")
+				inputMetrics.ResourceMetrics().AppendEmpty().Resource().Attributes().InsertString("cloud.platform", "azure_aks")
 				inputMetrics.ResourceMetrics().AppendEmpty().Resource().Attributes().InsertString("cloud.platform", "gcp_app_engine")
 				return inputMetrics
 			},
@@ -230,9 +234,8 @@ func TestAddCloudNamespaceForMetrics(t *testing.T) {
 				return inputMetrics
 			},
 			test: func(outputMetrics pmetric.Metrics) {
-				cloudNamespaceAttribute, found := outputMetrics.ResourceMetrics().At(0).Resource().Attributes().Get("cloud.namespace")
-				assert.True(t, found)
-				assert.Equal(t, "aws/ec2", cloudNamespaceAttribute.StringVal())
+				_, found := outputMetrics.ResourceMetrics().At(0).Resource().Attributes().Get("cloud.namespace")
+				assert.False(t, found)
 			},
 		},
 		{
@@ -337,7 +340,7 @@ func TestAddCloudNamespaceForTraces(t *testing.T) {
 				inputTraces := ptrace.NewTraces()
 				inputTraces.ResourceSpans().AppendEmpty().Resource().Attributes().InsertString("cloud.platform", "aws_eks")
 				inputTraces.ResourceSpans().AppendEmpty().Resource().Attributes().InsertString("cloud.platform", "aws_lambda")
-				inputTraces.ResourceSpans().AppendEmpty().Resource().Attributes().InsertString("cloud.platform", "azure_vm")
+				inputTraces.ResourceSpans().AppendEmpty().Resource().Attributes().InsertString("cloud.platform", "azure_aks")
 				inputTraces.ResourceSpans().AppendEmpty().Resource().Attributes().InsertString("cloud.platform", "gcp_app_engine")
 				return inputTraces
 			},
@@ -357,9 +360,8 @@ func TestAddCloudNamespaceForTraces(t *testing.T) {
 				return inputTraces
 			},
 			test: func(outputTraces ptrace.Traces) {
-				cloudNamespaceAttribute, found := outputTraces.ResourceSpans().At(0).Resource().Attributes().Get("cloud.namespace")
-				assert.True(t, found)
-				assert.Equal(t, "aws/ec2", cloudNamespaceAttribute.StringVal())
+				_, found := outputTraces.ResourceSpans().At(0).Resource().Attributes().Get("cloud.namespace")
+				assert.False(t, found)
 			},
 		},
 		{
@@ -663,6 +665,363 @@ func TestTranslateTelegrafMetrics(t *testing.T) {
 	}
 }
 
+func TestDropAttributesRunsAfterRenameAttributes(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.AddCloudNamespace = false
+	config.TranslateAttributes = false
+	config.TranslateTelegrafAttributes = false
+	config.RenameAttributes.Enabled = true
+	config.RenameAttributes.Mapping = map[string]string{"pod": "k8s.pod.name", "uid": "k8s.pod.uid"}
+	config.DropAttributes.Enabled = true
+	config.DropAttributes.Patterns = []string{"k8s.pod.uid"}
+
+	processor, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), config)
+	require.NoError(t, err)
+
+	inputLogs := plog.NewLogs()
+	attributes := inputLogs.ResourceLogs().AppendEmpty().Resource().Attributes()
+	attributes.InsertString("pod", "my-pod")
+	attributes.InsertString("uid", "my-uid")
+
+	outputLogs, err := processor.processLogs(context.Background(), inputLogs)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		map[string]interface{}{"k8s.pod.name": "my-pod"},
+		outputLogs.ResourceLogs().At(0).Resource().Attributes().AsRaw(),
+	)
+}
+
+func TestProcessorOrder(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.AddCloudNamespace = false
+	config.TranslateAttributes = false
+	config.TranslateTelegrafAttributes = false
+	config.RenameAttributes.Enabled = true
+	config.RenameAttributes.Mapping = map[string]string{"pod": "k8s.pod.name", "uid": "k8s.pod.uid"}
+	config.DropAttributes.Enabled = true
+	config.DropAttributes.Patterns = []string{"k8s.pod.uid"}
+	config.ProcessorOrder = []string{"drop_attributes", "rename_attributes"}
+
+	processor, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), config)
+	require.NoError(t, err)
+
+	inputLogs := plog.NewLogs()
+	attributes := inputLogs.ResourceLogs().AppendEmpty().Resource().Attributes()
+	attributes.InsertString("pod", "my-pod")
+	attributes.InsertString("uid", "my-uid")
+
+	outputLogs, err := processor.processLogs(context.Background(), inputLogs)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		map[string]interface{}{"k8s.pod.name": "my-pod", "k8s.pod.uid": "my-uid"},
+		outputLogs.ResourceLogs().At(0).Resource().Attributes().AsRaw(),
+	)
+}
+
+func TestProcessorOrderErrors(t *testing.T) {
+	testCases := []struct {
+		name        string
+		order       []string
+		expectedErr string
+	}{
+		{
+			name:        "unknown sub-processor",
+			order:       []string{"add_cloud_namespace", "translate_attributes", "translate_telegraf_attributes", "unknown"},
+			expectedErr: `processor_order: unknown or duplicate sub-processor "unknown"`,
+		},
+		{
+			name:        "missing enabled sub-processor",
+			order:       []string{"add_cloud_namespace", "translate_attributes"},
+			expectedErr: `processor_order: enabled sub-processor "translate_telegraf_attributes" is missing`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			config := createDefaultConfig().(*Config)
+			config.ProcessorOrder = testCase.order
+
+			_, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), config)
+			assert.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
+
+// TestConcurrentProcessing is meant to be run with the race detector to check
+// that the processor can be used by multiple goroutines at the same time.
+func TestIncludeExemplars(t *testing.T) {
+	createMetrics := func() pmetric.Metrics {
+		metrics := pmetric.NewMetrics()
+		metricsSlice := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+
+		sum := metricsSlice.AppendEmpty()
+		sum.SetDataType(pmetric.MetricDataTypeSum)
+		sumDataPoint := sum.Sum().DataPoints().AppendEmpty()
+		sumDataPoint.Attributes().InsertString("pod", "my-pod")
+		sumDataPoint.Exemplars().AppendEmpty().FilteredAttributes().InsertString("pod", "exemplar-pod")
+
+		histogram := metricsSlice.AppendEmpty()
+		histogram.SetDataType(pmetric.MetricDataTypeHistogram)
+		histogram.Histogram().DataPoints().AppendEmpty().Exemplars().AppendEmpty().FilteredAttributes().InsertString("pod", "exemplar-pod")
+
+		return metrics
+	}
+
+	testCases := []struct {
+		name             string
+		includeExemplars bool
+		expectedKey      string
+	}{
+		{
+			name:             "exemplars are processed when enabled",
+			includeExemplars: true,
+			expectedKey:      "k8s.pod.name",
+		},
+		{
+			name:             "exemplars are not processed by default",
+			includeExemplars: false,
+			expectedKey:      "pod",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			config := createDefaultConfig().(*Config)
+			config.RenameAttributes.Enabled = true
+			config.RenameAttributes.Mapping = map[string]string{"pod": "k8s.pod.name"}
+			config.IncludeExemplars = testCase.includeExemplars
+
+			processor, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), config)
+			require.NoError(t, err)
+
+			metrics, err := processor.processMetrics(context.Background(), createMetrics())
+			require.NoError(t, err)
+
+			metricsSlice := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+			sumDataPoint := metricsSlice.At(0).Sum().DataPoints().At(0)
+			assert.Equal(t, map[string]interface{}{"k8s.pod.name": "my-pod"}, sumDataPoint.Attributes().AsRaw())
+			assert.Equal(t,
+				map[string]interface{}{testCase.expectedKey: "exemplar-pod"},
+				sumDataPoint.Exemplars().At(0).FilteredAttributes().AsRaw(),
+			)
+			assert.Equal(t,
+				map[string]interface{}{testCase.expectedKey: "exemplar-pod"},
+				metricsSlice.At(1).Histogram().DataPoints().At(0).Exemplars().At(0).FilteredAttributes().AsRaw(),
+			)
+		})
+	}
+}
+
+func TestConcurrentProcessing(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.CloudNamespaceMappings = map[string]string{"aws_ec2": "aws/ec2"}
+	config.RenameAttributes.Enabled = true
+	config.RenameAttributes.Mapping = map[string]string{"pod": "k8s.pod.name"}
+	config.RedactAttributes.Enabled = true
+	config.RedactAttributes.Patterns = []string{"*password*"}
+	config.NormalizeKeys.Enabled = true
+	config.TrimAttributes.Enabled = true
+	config.TrimAttributes.Patterns = []string{"*"}
+	config.TranslateMetricNames.Enabled = true
+	config.TranslateMetricNames.Rules = []MetricNameRule{{Pattern: "system.*", Replacement: "sys_*"}}
+	config.MapSeverity.Enabled = true
+	config.DedupeAttributes = true
+	config.Conditions = map[string]ConditionConfig{
+		"trim_attributes": {Attribute: "k8s.pod.name", Regex: "^my-"},
+	}
+
+	processor, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), config)
+	require.NoError(t, err)
+
+	const goroutines = 8
+	const iterations = 50
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < iterations; j++ {
+				logs := plog.NewLogs()
+				resourceLogs := logs.ResourceLogs().AppendEmpty()
+				resourceLogs.Resource().Attributes().InsertString("cloud.platform", "aws_ec2")
+				resourceLogs.Resource().Attributes().InsertString("pod", "my-pod")
+				logRecord := resourceLogs.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+				logRecord.SetSeverityNumber(plog.SeverityNumberWARN)
+				logRecord.Attributes().InsertString("db.password", " secret ")
+				_, err := processor.processLogs(context.Background(), logs)
+				assert.NoError(t, err)
+
+				metrics := pmetric.NewMetrics()
+				resourceMetrics := metrics.ResourceMetrics().AppendEmpty()
+				resourceMetrics.Resource().Attributes().InsertString("pod", "my-pod")
+				metric := resourceMetrics.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+				metric.SetName("system.cpu.usage")
+				metric.SetDataType(pmetric.MetricDataTypeGauge)
+				metric.Gauge().DataPoints().AppendEmpty().Attributes().InsertString("state", " idle ")
+				_, err = processor.processMetrics(context.Background(), metrics)
+				assert.NoError(t, err)
+
+				traces := ptrace.NewTraces()
+				resourceSpans := traces.ResourceSpans().AppendEmpty()
+				resourceSpans.Resource().Attributes().InsertString("pod", "other-pod")
+				resourceSpans.ScopeSpans().AppendEmpty().Spans().AppendEmpty().Attributes().InsertString("user.password", "secret")
+				_, err = processor.processTraces(context.Background(), traces)
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestAttributesSubprocessorsGroupedTraversal(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.AddCloudNamespace = false
+	config.TranslateAttributes = false
+	config.TranslateTelegrafAttributes = false
+	config.RenameAttributes.Enabled = true
+	config.RenameAttributes.Mapping = map[string]string{"pod": "k8s.pod.name"}
+	config.CopyAttributes.Enabled = true
+	config.CopyAttributes.Attributes = []CopyAttributePair{{From: "k8s.pod.name", To: "pod_name"}}
+	config.DropAttributes.Enabled = true
+	config.DropAttributes.Patterns = []string{"k8s.*"}
+	config.TrimAttributes.Enabled = true
+	config.TrimAttributes.Patterns = []string{"*"}
+	config.TranslateMetricNames.Enabled = true
+	config.TranslateMetricNames.Mapping = map[string]string{"cpu": "cpu_usage"}
+	config.PrefixAttributes.Enabled = true
+	config.PrefixAttributes.Patterns = []string{"pod_*"}
+	config.PrefixAttributes.Affix = "sumo."
+	config.ProcessorOrder = []string{
+		"rename_attributes", "copy_attributes", "drop_attributes", "trim_attributes", "translate_metric_names", "prefix_attributes",
+	}
+
+	processor, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), config)
+	require.NoError(t, err)
+
+	// rename, copy, drop and trim are grouped, translate_metric_names and prefix are not.
+	require.Len(t, processor.enabledSubprocessors, 6)
+	require.Len(t, processor.steps, 3)
+	assert.Equal(t, "rename_attributes, copy_attributes, drop_attributes, trim_attributes", processor.steps[0].ConfigPropertyName())
+
+	t.Run("logs", func(t *testing.T) {
+		logs := plog.NewLogs()
+		resourceLogs := logs.ResourceLogs().AppendEmpty()
+		resourceLogs.Resource().Attributes().InsertString("pod", " my-pod ")
+		resourceLogs.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().InsertString("pod", "other-pod ")
+
+		expected := logs.Clone()
+		for _, subprocessor := range processor.enabledSubprocessors {
+			require.NoError(t, subprocessor.ProcessLogs(expected))
+		}
+
+		actual, err := processor.processLogs(context.Background(), logs)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+		assert.Equal(t,
+			map[string]interface{}{"sumo.pod_name": "my-pod"},
+			actual.ResourceLogs().At(0).Resource().Attributes().AsRaw(),
+		)
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		metrics := pmetric.NewMetrics()
+		resourceMetrics := metrics.ResourceMetrics().AppendEmpty()
+		resourceMetrics.Resource().Attributes().InsertString("pod", " my-pod ")
+		metric := resourceMetrics.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		metric.SetName("cpu")
+		metric.SetDataType(pmetric.MetricDataTypeSum)
+		metric.Sum().DataPoints().AppendEmpty().Attributes().InsertString("pod", "other-pod ")
+
+		expected := metrics.Clone()
+		for _, subprocessor := range processor.enabledSubprocessors {
+			require.NoError(t, subprocessor.ProcessMetrics(expected))
+		}
+
+		actual, err := processor.processMetrics(context.Background(), metrics)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+		assert.Equal(t, "cpu_usage", actual.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+	})
+
+	t.Run("traces", func(t *testing.T) {
+		traces := ptrace.NewTraces()
+		resourceSpans := traces.ResourceSpans().AppendEmpty()
+		resourceSpans.Resource().Attributes().InsertString("pod", " my-pod ")
+		resourceSpans.ScopeSpans().AppendEmpty().Spans().AppendEmpty().Attributes().InsertString("pod", "other-pod ")
+
+		expected := traces.Clone()
+		for _, subprocessor := range processor.enabledSubprocessors {
+			require.NoError(t, subprocessor.ProcessTraces(expected))
+		}
+
+		actual, err := processor.processTraces(context.Background(), traces)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+}
+
+func TestSubprocessorsStartAndShutdown(t *testing.T) {
+	processor, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), newCloudNamespaceConfig(true))
+	require.NoError(t, err)
+
+	plain := &lifecycleSubprocessor{}
+	conditional := &lifecycleSubprocessor{}
+	condition, err := newAttributeCondition(ConditionConfig{Attribute: "k8s.pod.name"})
+	require.NoError(t, err)
+	processor.enabledSubprocessors = append(processor.enabledSubprocessors, plain, newConditionalSubprocessor(conditional, condition))
+
+	require.NoError(t, processor.start(context.Background(), componenttest.NewNopHost()))
+	assert.True(t, plain.started)
+	assert.True(t, conditional.started)
+
+	require.NoError(t, processor.shutdown(context.Background()))
+	assert.True(t, plain.shutDown)
+	assert.True(t, conditional.shutDown)
+}
+
+func TestSubprocessorsStartAndShutdownErrors(t *testing.T) {
+	processor, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), newCloudNamespaceConfig(true))
+	require.NoError(t, err)
+
+	failing := &lifecycleSubprocessor{err: errors.New("mapping file not found")}
+	other := &lifecycleSubprocessor{}
+	processor.enabledSubprocessors = append(processor.enabledSubprocessors, failing, other)
+
+	err = processor.start(context.Background(), componenttest.NewNopHost())
+	assert.EqualError(t, err, "failed to start property lifecycle: mapping file not found")
+	assert.False(t, other.started)
+
+	err = processor.shutdown(context.Background())
+	assert.EqualError(t, err, "failed to shut down property lifecycle: mapping file not found")
+	assert.True(t, other.shutDown)
+}
+
+// lifecycleSubprocessor is a sub-processor which records whether it was started and shut down.
+type lifecycleSubprocessor struct {
+	started  bool
+	shutDown bool
+	err      error
+}
+
+func (proc *lifecycleSubprocessor) Start(context.Context, component.Host) error {
+	proc.started = proc.err == nil
+	return proc.err
+}
+
+func (proc *lifecycleSubprocessor) Shutdown(context.Context) error {
+	proc.shutDown = proc.err == nil
+	return proc.err
+}
+
+func (*lifecycleSubprocessor) ProcessLogs(plog.Logs) error          { return nil }
+func (*lifecycleSubprocessor) ProcessMetrics(pmetric.Metrics) error { return nil }
+func (*lifecycleSubprocessor) ProcessTraces(ptrace.Traces) error    { return nil }
+func (*lifecycleSubprocessor) IsEnabled() bool                      { return true }
+func (*lifecycleSubprocessor) ConfigPropertyName() string           { return "lifecycle" }
+
 func newProcessorCreateSettings() component.ProcessorCreateSettings {
 	return component.ProcessorCreateSettings{
 		TelemetrySettings: component.TelemetrySettings{
@@ -694,3 +1053,232 @@ func newTranslateTelegrafAttributesConfig(translateTelegrafAttributes bool) *Con
 	config.TranslateTelegrafAttributes = translateTelegrafAttributes
 	return config
 }
+
+func TestSignals(t *testing.T) {
+	testCases := []struct {
+		name              string
+		signals           []string
+		expectedProcessed map[string]bool
+	}{
+		{
+			name:              "all signals by default",
+			signals:           []string{},
+			expectedProcessed: map[string]bool{"logs": true, "metrics": true, "traces": true},
+		},
+		{
+			name:              "logs only",
+			signals:           []string{"logs"},
+			expectedProcessed: map[string]bool{"logs": true, "metrics": false, "traces": false},
+		},
+		{
+			name:              "metrics and traces",
+			signals:           []string{"metrics", "traces"},
+			expectedProcessed: map[string]bool{"logs": false, "metrics": true, "traces": true},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			config := newCloudNamespaceConfig(true)
+			config.Signals = testCase.signals
+			processor, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), config)
+			require.NoError(t, err)
+
+			logs := plog.NewLogs()
+			logs.ResourceLogs().AppendEmpty().Resource().Attributes().InsertString("cloud.platform", "aws_ec2")
+			expectedLogs := logs.Clone()
+			logs, err = processor.processLogs(context.Background(), logs)
+			require.NoError(t, err)
+
+			metrics := pmetric.NewMetrics()
+			metrics.ResourceMetrics().AppendEmpty().Resource().Attributes().InsertString("cloud.platform", "aws_ec2")
+			expectedMetrics := metrics.Clone()
+			metrics, err = processor.processMetrics(context.Background(), metrics)
+			require.NoError(t, err)
+
+			traces := ptrace.NewTraces()
+			traces.ResourceSpans().AppendEmpty().Resource().Attributes().InsertString("cloud.platform", "aws_ec2")
+			expectedTraces := traces.Clone()
+			traces, err = processor.processTraces(context.Background(), traces)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expectedProcessed["logs"], logs.ResourceLogs().At(0).Resource().Attributes().Len() == 2)
+			assert.Equal(t, testCase.expectedProcessed["metrics"], metrics.ResourceMetrics().At(0).Resource().Attributes().Len() == 2)
+			assert.Equal(t, testCase.expectedProcessed["traces"], traces.ResourceSpans().At(0).Resource().Attributes().Len() == 2)
+
+			if !testCase.expectedProcessed["logs"] {
+				assert.Equal(t, expectedLogs, logs)
+			}
+			if !testCase.expectedProcessed["metrics"] {
+				assert.Equal(t, expectedMetrics, metrics)
+			}
+			if !testCase.expectedProcessed["traces"] {
+				assert.Equal(t, expectedTraces, traces)
+			}
+		})
+	}
+}
+
+func BenchmarkProcessLogs(b *testing.B) {
+	processor, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), newCloudNamespaceConfig(true))
+	require.NoError(b, err)
+
+	createLogs := func() plog.Logs {
+		logs := plog.NewLogs()
+		for i := 0; i < 100; i++ {
+			resourceLogs := logs.ResourceLogs().AppendEmpty()
+			resourceLogs.Resource().Attributes().InsertString("cloud.platform", "aws_ec2")
+			logRecords := resourceLogs.ScopeLogs().AppendEmpty().LogRecords()
+			for j := 0; j < 10; j++ {
+				logRecords.AppendEmpty().Attributes().InsertString("key", "value")
+			}
+		}
+		return logs
+	}
+
+	b.Run("enabled sub-processors", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			logs := createLogs()
+			_, _ = processor.processLogs(context.Background(), logs)
+		}
+	})
+
+	b.Run("disabled sub-processors", func(b *testing.B) {
+		disabledProcessor, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), newCloudNamespaceConfig(false))
+		require.NoError(b, err)
+		require.False(b, disabledProcessor.enabled())
+		logs := createLogs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, _ = disabledProcessor.processLogs(context.Background(), logs)
+		}
+	})
+
+	b.Run("all sub-processors", func(b *testing.B) {
+		allProcessor := *processor
+		allProcessor.steps = allProcessor.subprocessors
+		for i := 0; i < b.N; i++ {
+			logs := createLogs()
+			_, _ = allProcessor.processLogs(context.Background(), logs)
+		}
+	})
+}
+
+// newAllEnabledConfig returns a configuration with every sub-processor enabled.
+func newAllEnabledConfig() *Config {
+	config := createDefaultConfig().(*Config)
+	config.TranslateAttributesScope = translateScopeAll
+	config.RedactAttributes = &RedactAttributesConfig{Enabled: true, Patterns: []string{"*password*"}, Action: "mask"}
+	config.RenameAttributes.Enabled = true
+	config.RenameAttributes.Mapping = map[string]string{"pod": "k8s.pod.name"}
+	config.CopyAttributes = &CopyAttributesConfig{Enabled: true, Attributes: []CopyAttributePair{{From: "host", To: "host.name"}}}
+	config.TemplateAttributes = &TemplateAttributesConfig{Enabled: true, Attributes: []TemplateAttribute{{Key: "endpoint", Template: "{host.name}:{port}"}}, Missing: templateMissingSkip}
+	config.DropAttributes = &DropAttributesConfig{Enabled: true, Patterns: []string{"secret"}, MatchOn: matchOnKey}
+	config.NormalizeKeys.Enabled = true
+	config.RewriteKeys = &RewriteKeysConfig{Enabled: true, Pattern: "__", Replacement: "."}
+	config.CoerceAttributes = &CoerceAttributesConfig{Enabled: true, Patterns: []string{"count"}, Type: "int"}
+	config.NormalizeBooleans.Enabled = true
+	config.NormalizeBooleans.Patterns = []string{"*.enabled"}
+	config.SplitAttributes = &SplitAttributesConfig{Enabled: true, Attributes: []string{"tags"}, PairSeparator: ",", KeyValueSeparator: "="}
+	config.TrimAttributes = &TrimAttributesConfig{Enabled: true, Patterns: []string{"*"}}
+	config.LimitAttributeLength = &LimitAttributeLengthConfig{Enabled: true, Patterns: []string{"*"}, MaxBytes: 100}
+	config.PrefixAttributes = &AffixAttributesConfig{Enabled: true, Patterns: []string{"custom"}, Affix: "my."}
+	config.SuffixAttributes = &AffixAttributesConfig{Enabled: true, Patterns: []string{"other"}, Affix: ".suffix"}
+	config.MaxCardinality = &MaxCardinalityConfig{Enabled: true, Keys: []string{"user.id"}, Limit: 10, Window: time.Minute, Action: maxCardinalityActionDrop}
+	config.DefaultAttributes = &DefaultAttributesConfig{Enabled: true, Attributes: []DefaultAttribute{{Key: "env", Value: "prod"}}, Scope: defaultAttributesScopeAll}
+	config.MoveAttributes.Enabled = true
+	config.MoveAttributes.Patterns = []string{"host.name"}
+	config.DedupeAttributes = true
+	config.ParseJSONAttributes = &ParseJSONAttributesConfig{Enabled: true, Attribute: "json"}
+	config.TranslateMetricNames.Enabled = true
+	config.TranslateMetricNames.Rules = []MetricNameRule{{Pattern: "system.*", Replacement: "sys_*"}}
+	config.PromoteBodyToAttributes.Enabled = true
+	config.MapSeverity.Enabled = true
+	config.SetTimestampFromAttribute = &SetTimestampFromAttributeConfig{Enabled: true, Attribute: "time"}
+	config.IncludeExemplars = true
+	config.Conditions = map[string]ConditionConfig{
+		"trim_attributes": {Attribute: "k8s.pod.name", Regex: "^my-"},
+	}
+	return config
+}
+
+// insertEmptyValues inserts attributes without values under keys used by newAllEnabledConfig.
+func insertEmptyValues(attributes pcommon.Map) {
+	for _, key := range []string{"password", "pod", "host", "secret", "count", "tags", "custom", "other", "json", "time"} {
+		attributes.Insert(key, pcommon.NewValueEmpty())
+	}
+}
+
+func TestEmptyInput(t *testing.T) {
+	config := newAllEnabledConfig()
+	require.NoError(t, config.Validate())
+
+	for _, dryRun := range []bool{false, true} {
+		config.DryRun = dryRun
+		processor, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), config)
+		require.NoError(t, err)
+
+		t.Run("logs", func(t *testing.T) {
+			logs := plog.NewLogs()
+			result, err := processor.processLogs(context.Background(), logs)
+			require.NoError(t, err)
+			assert.Equal(t, 0, result.ResourceLogs().Len())
+
+			resourceLogs := logs.ResourceLogs()
+			resourceLogs.AppendEmpty()
+			resourceLogs.AppendEmpty().ScopeLogs().AppendEmpty()
+			resourceLogs.AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+			insertEmptyValues(resourceLogs.At(2).ScopeLogs().At(0).LogRecords().AppendEmpty().Attributes())
+			result, err = processor.processLogs(context.Background(), logs)
+			require.NoError(t, err)
+			assert.Equal(t, 3, result.ResourceLogs().Len())
+		})
+
+		t.Run("metrics", func(t *testing.T) {
+			metrics := pmetric.NewMetrics()
+			result, err := processor.processMetrics(context.Background(), metrics)
+			require.NoError(t, err)
+			assert.Equal(t, 0, result.ResourceMetrics().Len())
+
+			resourceMetrics := metrics.ResourceMetrics()
+			resourceMetrics.AppendEmpty()
+			resourceMetrics.AppendEmpty().ScopeMetrics().AppendEmpty()
+			metricsSlice := resourceMetrics.AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+			metricsSlice.AppendEmpty()
+			gauge := metricsSlice.AppendEmpty()
+			gauge.SetDataType(pmetric.MetricDataTypeGauge)
+			dataPoint := gauge.Gauge().DataPoints().AppendEmpty()
+			insertEmptyValues(dataPoint.Attributes())
+			insertEmptyValues(dataPoint.Exemplars().AppendEmpty().FilteredAttributes())
+			for _, dataType := range []pmetric.MetricDataType{
+				pmetric.MetricDataTypeGauge,
+				pmetric.MetricDataTypeSum,
+				pmetric.MetricDataTypeHistogram,
+				pmetric.MetricDataTypeExponentialHistogram,
+				pmetric.MetricDataTypeSummary,
+			} {
+				metricsSlice.AppendEmpty().SetDataType(dataType)
+			}
+			result, err = processor.processMetrics(context.Background(), metrics)
+			require.NoError(t, err)
+			assert.Equal(t, 3, result.ResourceMetrics().Len())
+			assert.Equal(t, 7, result.ResourceMetrics().At(2).ScopeMetrics().At(0).Metrics().Len())
+		})
+
+		t.Run("traces", func(t *testing.T) {
+			traces := ptrace.NewTraces()
+			result, err := processor.processTraces(context.Background(), traces)
+			require.NoError(t, err)
+			assert.Equal(t, 0, result.ResourceSpans().Len())
+
+			resourceSpans := traces.ResourceSpans()
+			resourceSpans.AppendEmpty()
+			resourceSpans.AppendEmpty().ScopeSpans().AppendEmpty()
+			resourceSpans.AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			insertEmptyValues(resourceSpans.At(2).ScopeSpans().At(0).Spans().AppendEmpty().Attributes())
+			result, err = processor.processTraces(context.Background(), traces)
+			require.NoError(t, err)
+			assert.Equal(t, 3, result.ResourceSpans().Len())
+		})
+	}
+}
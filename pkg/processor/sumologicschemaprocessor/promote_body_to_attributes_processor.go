@@ -0,0 +1,102 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// PromoteBodyToAttributesConfig configures the promote_body_to_attributes sub-processor.
+type PromoteBodyToAttributesConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Prefix is prepended to the keys of body entries.
+	Prefix string `mapstructure:"prefix"`
+	// RemoveFromBody defines whether promoted entries should be removed from the body.
+	RemoveFromBody bool `mapstructure:"remove_from_body"`
+	// Overwrite defines whether an attribute which already has the key of a body entry should be overwritten.
+	Overwrite bool `mapstructure:"overwrite"`
+}
+
+// promoteBodyToAttributesProcessor copies entries of map log bodies to log record attributes.
+type promoteBodyToAttributesProcessor struct {
+	enabled        bool
+	prefix         string
+	removeFromBody bool
+	overwrite      bool
+}
+
+func newPromoteBodyToAttributesProcessor(config *PromoteBodyToAttributesConfig) (*promoteBodyToAttributesProcessor, error) {
+	return &promoteBodyToAttributesProcessor{
+		enabled:        config.Enabled,
+		prefix:         config.Prefix,
+		removeFromBody: config.RemoveFromBody,
+		overwrite:      config.Overwrite,
+	}, nil
+}
+
+func (proc *promoteBodyToAttributesProcessor) ProcessLogs(logs plog.Logs) error {
+	if proc.enabled {
+		processLogRecords(logs, proc.promoteBody)
+	}
+	return nil
+}
+
+func (proc *promoteBodyToAttributesProcessor) ProcessMetrics(_ pmetric.Metrics) error {
+	// No-op, this subprocessor doesn't process metrics.
+	return nil
+}
+
+func (proc *promoteBodyToAttributesProcessor) ProcessTraces(_ ptrace.Traces) error {
+	// No-op, this subprocessor doesn't process traces.
+	return nil
+}
+
+func (proc *promoteBodyToAttributesProcessor) IsEnabled() bool {
+	return proc.enabled
+}
+
+func (*promoteBodyToAttributesProcessor) ConfigPropertyName() string {
+	return "promote_body_to_attributes"
+}
+
+func (proc *promoteBodyToAttributesProcessor) promoteBody(logRecord plog.LogRecord) {
+	if logRecord.Body().Type() != pcommon.ValueTypeMap {
+		return
+	}
+
+	body := logRecord.Body().MapVal()
+	attributes := logRecord.Attributes()
+	promoted := make(map[string]struct{}, body.Len())
+
+	body.Range(func(key string, value pcommon.Value) bool {
+		attributeKey := proc.prefix + key
+		if _, exists := attributes.Get(attributeKey); exists && !proc.overwrite {
+			return true
+		}
+		attributes.Upsert(attributeKey, value)
+		promoted[key] = struct{}{}
+		return true
+	})
+
+	if proc.removeFromBody && len(promoted) > 0 {
+		body.RemoveIf(func(key string, _ pcommon.Value) bool {
+			_, ok := promoted[key]
+			return ok
+		})
+	}
+}
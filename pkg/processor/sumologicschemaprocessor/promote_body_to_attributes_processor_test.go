@@ -0,0 +1,107 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestPromoteBodyToAttributes(t *testing.T) {
+	testCases := []struct {
+		name               string
+		config             PromoteBodyToAttributesConfig
+		expectedAttributes map[string]interface{}
+		expectedBody       map[string]interface{}
+	}{
+		{
+			name:               "copies body entries",
+			config:             PromoteBodyToAttributesConfig{Enabled: true},
+			expectedAttributes: map[string]interface{}{"host": "a", "level": "info", "count": int64(3)},
+			expectedBody:       map[string]interface{}{"host": "b", "level": "info", "count": int64(3)},
+		},
+		{
+			name:               "prefix",
+			config:             PromoteBodyToAttributesConfig{Enabled: true, Prefix: "body."},
+			expectedAttributes: map[string]interface{}{"host": "a", "body.host": "b", "body.level": "info", "body.count": int64(3)},
+			expectedBody:       map[string]interface{}{"host": "b", "level": "info", "count": int64(3)},
+		},
+		{
+			name:               "overwrite",
+			config:             PromoteBodyToAttributesConfig{Enabled: true, Overwrite: true},
+			expectedAttributes: map[string]interface{}{"host": "b", "level": "info", "count": int64(3)},
+			expectedBody:       map[string]interface{}{"host": "b", "level": "info", "count": int64(3)},
+		},
+		{
+			name:               "remove from body keeps skipped entries",
+			config:             PromoteBodyToAttributesConfig{Enabled: true, RemoveFromBody: true},
+			expectedAttributes: map[string]interface{}{"host": "a", "level": "info", "count": int64(3)},
+			expectedBody:       map[string]interface{}{"host": "b"},
+		},
+		{
+			name:               "disabled",
+			config:             PromoteBodyToAttributesConfig{Enabled: false, RemoveFromBody: true},
+			expectedAttributes: map[string]interface{}{"host": "a"},
+			expectedBody:       map[string]interface{}{"host": "b", "level": "info", "count": int64(3)},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			processor, err := newPromoteBodyToAttributesProcessor(&testCase.config)
+			require.NoError(t, err)
+
+			logs := plog.NewLogs()
+			logRecord := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+			logRecord.Attributes().InsertString("host", "a")
+			pcommon.NewValueMap().CopyTo(logRecord.Body())
+			logRecord.Body().MapVal().InsertString("host", "b")
+			logRecord.Body().MapVal().InsertString("level", "info")
+			logRecord.Body().MapVal().InsertInt("count", 3)
+
+			require.NoError(t, processor.ProcessLogs(logs))
+
+			assert.Equal(t, testCase.expectedAttributes, logRecord.Attributes().AsRaw())
+			assert.Equal(t, testCase.expectedBody, logRecord.Body().MapVal().AsRaw())
+		})
+	}
+}
+
+func TestPromoteBodyToAttributesNonMapBody(t *testing.T) {
+	processor, err := newPromoteBodyToAttributesProcessor(&PromoteBodyToAttributesConfig{Enabled: true, RemoveFromBody: true})
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	logRecords := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	logRecords.AppendEmpty().Body().SetStringVal(`{"host": "a"}`)
+	sliceBody := pcommon.NewValueSlice()
+	sliceBody.SliceVal().AppendEmpty().SetStringVal("a")
+	sliceBody.CopyTo(logRecords.AppendEmpty().Body())
+	logRecords.AppendEmpty()
+
+	require.NoError(t, processor.ProcessLogs(logs))
+
+	require.Equal(t, 3, logRecords.Len())
+	assert.Equal(t, `{"host": "a"}`, logRecords.At(0).Body().StringVal())
+	assert.Equal(t, []interface{}{"a"}, logRecords.At(1).Body().SliceVal().AsRaw())
+	assert.Equal(t, pcommon.ValueTypeEmpty, logRecords.At(2).Body().Type())
+	for i := 0; i < logRecords.Len(); i++ {
+		assert.Equal(t, 0, logRecords.At(i).Attributes().Len())
+	}
+}
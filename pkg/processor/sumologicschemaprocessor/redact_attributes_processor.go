@@ -0,0 +1,143 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	redactActionHashSha256 = "hash_sha256"
+	redactActionMask       = "mask"
+	redactActionRemove     = "remove"
+)
+
+// RedactAttributesConfig configures the redact_attributes sub-processor.
+type RedactAttributesConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Patterns are attribute keys to redact. The `*` character matches any sequence of characters.
+	Patterns []string `mapstructure:"patterns"`
+	// WildcardOptions change how Patterns are matched.
+	WildcardOptions `mapstructure:",squash"`
+	// Action is one of `hash_sha256`, `mask` or `remove`.
+	Action string `mapstructure:"action"`
+}
+
+// redactAttributesProcessor hides the values of attributes which may contain sensitive data.
+type redactAttributesProcessor struct {
+	enabled      bool
+	action       string
+	regexes      []*regexp.Regexp
+	maxKeyLength int
+}
+
+func newRedactAttributesProcessor(config *RedactAttributesConfig) (*redactAttributesProcessor, error) {
+	if err := validateRedactAction(config.Action); err != nil {
+		return nil, err
+	}
+
+	regexes, err := compileWildcards(config.Patterns, config.WildcardOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &redactAttributesProcessor{
+		enabled: config.Enabled,
+		action:  config.Action,
+		regexes: regexes,
+	}, nil
+}
+
+func validateRedactAction(action string) error {
+	switch action {
+	case redactActionHashSha256, redactActionMask, redactActionRemove:
+		return nil
+	default:
+		return fmt.Errorf("invalid redact action: %q", action)
+	}
+}
+
+func (proc *redactAttributesProcessor) ProcessLogs(logs plog.Logs) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnLogs(proc, logs)
+	}
+	return nil
+}
+
+func (proc *redactAttributesProcessor) ProcessMetrics(metrics pmetric.Metrics) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnMetrics(proc, metrics)
+	}
+	return nil
+}
+
+func (proc *redactAttributesProcessor) ProcessTraces(traces ptrace.Traces) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnTraces(proc, traces)
+	}
+	return nil
+}
+
+func (proc *redactAttributesProcessor) IsEnabled() bool {
+	return proc.enabled
+}
+
+func (*redactAttributesProcessor) ConfigPropertyName() string {
+	return "redact_attributes"
+}
+
+func (proc *redactAttributesProcessor) setMaxKeyLength(maxKeyLength int) {
+	proc.maxKeyLength = maxKeyLength
+}
+
+func (proc *redactAttributesProcessor) processAttributes(attributes pcommon.Map) int {
+	if proc.action == redactActionRemove {
+		removed := 0
+		attributes.RemoveIf(func(key string, _ pcommon.Value) bool {
+			if matchesAnyKeyRegex(proc.regexes, key, proc.maxKeyLength) {
+				removed++
+				return true
+			}
+			return false
+		})
+		return removed
+	}
+
+	attributes.Range(func(key string, value pcommon.Value) bool {
+		if !matchesAnyKeyRegex(proc.regexes, key, proc.maxKeyLength) {
+			return true
+		}
+
+		switch proc.action {
+		case redactActionHashSha256:
+			sum := sha256.Sum256([]byte(value.AsString()))
+			value.SetStringVal(hex.EncodeToString(sum[:]))
+		case redactActionMask:
+			value.SetStringVal(strings.Repeat("*", utf8.RuneCountInString(value.AsString())))
+		}
+		return true
+	})
+	return 0
+}
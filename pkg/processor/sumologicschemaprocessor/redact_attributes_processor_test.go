@@ -0,0 +1,165 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestRedactAttributes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		action   string
+		input    map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name:   "hashes matching attributes",
+			action: "hash_sha256",
+			input: map[string]interface{}{
+				"user.email": "john@example.com",
+				"user.name":  "John",
+			},
+			expected: map[string]interface{}{
+				"user.email": "855f96e983f1f8e8be944692b6f719fd54329826cb62e98015efee8e2e071dd4",
+				"user.name":  "John",
+			},
+		},
+		{
+			name:   "masks matching attributes",
+			action: "mask",
+			input: map[string]interface{}{
+				"user.email":   "żółw@example.com",
+				"access.token": "secret",
+			},
+			expected: map[string]interface{}{
+				"user.email":   "****************",
+				"access.token": "******",
+			},
+		},
+		{
+			name:   "removes matching attributes",
+			action: "remove",
+			input: map[string]interface{}{
+				"user.email":   "john@example.com",
+				"access.token": "secret",
+				"user.name":    "John",
+			},
+			expected: map[string]interface{}{
+				"user.name": "John",
+			},
+		},
+		{
+			name:   "stringifies non-string values",
+			action: "mask",
+			input: map[string]interface{}{
+				"user.email": int64(1234),
+			},
+			expected: map[string]interface{}{
+				"user.email": "****",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			processor, err := newRedactAttributesProcessor(&RedactAttributesConfig{
+				Enabled:  true,
+				Patterns: []string{"user.email", "*.token"},
+				Action:   testCase.action,
+			})
+			require.NoError(t, err)
+
+			attributes := pcommon.NewMapFromRaw(testCase.input)
+			processor.processAttributes(attributes)
+
+			assert.Equal(t, testCase.expected, attributes.AsRaw())
+		})
+	}
+}
+
+func TestRedactAttributesMatchesWholeKey(t *testing.T) {
+	processor, err := newRedactAttributesProcessor(&RedactAttributesConfig{
+		Enabled:  true,
+		Patterns: []string{"token", "user.*"},
+		Action:   "remove",
+	})
+	require.NoError(t, err)
+
+	attributes := pcommon.NewMapFromRaw(map[string]interface{}{
+		"token":        "a",
+		"access.token": "b",
+		"tokens":       "c",
+		"user.email":   "d",
+		"userXemail":   "e",
+	})
+	processor.processAttributes(attributes)
+
+	assert.Equal(t, map[string]interface{}{
+		"access.token": "b",
+		"tokens":       "c",
+		"userXemail":   "e",
+	}, attributes.AsRaw())
+}
+
+func TestRedactAttributesInvalidAction(t *testing.T) {
+	_, err := newRedactAttributesProcessor(&RedactAttributesConfig{
+		Enabled: true,
+		Action:  "encrypt",
+	})
+	assert.EqualError(t, err, `invalid redact action: "encrypt"`)
+}
+
+func TestRedactAttributesAllSignals(t *testing.T) {
+	processor, err := newRedactAttributesProcessor(&RedactAttributesConfig{
+		Enabled:  true,
+		Patterns: []string{"secret"},
+		Action:   "remove",
+	})
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	resourceLogs.Resource().Attributes().InsertString("secret", "a")
+	resourceLogs.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().InsertString("secret", "b")
+	require.NoError(t, processor.ProcessLogs(logs))
+	assert.Equal(t, 0, logs.ResourceLogs().At(0).Resource().Attributes().Len())
+	assert.Equal(t, 0, logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Len())
+
+	metrics := pmetric.NewMetrics()
+	resourceMetrics := metrics.ResourceMetrics().AppendEmpty()
+	resourceMetrics.Resource().Attributes().InsertString("secret", "a")
+	metric := resourceMetrics.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetDataType(pmetric.MetricDataTypeSum)
+	metric.Sum().DataPoints().AppendEmpty().Attributes().InsertString("secret", "b")
+	require.NoError(t, processor.ProcessMetrics(metrics))
+	assert.Equal(t, 0, metrics.ResourceMetrics().At(0).Resource().Attributes().Len())
+	assert.Equal(t, 0, metric.Sum().DataPoints().At(0).Attributes().Len())
+
+	traces := ptrace.NewTraces()
+	resourceSpans := traces.ResourceSpans().AppendEmpty()
+	resourceSpans.Resource().Attributes().InsertString("secret", "a")
+	resourceSpans.ScopeSpans().AppendEmpty().Spans().AppendEmpty().Attributes().InsertString("secret", "b")
+	require.NoError(t, processor.ProcessTraces(traces))
+	assert.Equal(t, 0, traces.ResourceSpans().At(0).Resource().Attributes().Len())
+	assert.Equal(t, 0, traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Len())
+}
@@ -0,0 +1,256 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// RenameAttributesConfig configures the rename_attributes sub-processor.
+type RenameAttributesConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Mapping maps old attribute names to new attribute names.
+	Mapping map[string]string `mapstructure:"mapping"`
+	// Overwrite defines whether an attribute which already has the new name should be overwritten.
+	Overwrite bool `mapstructure:"overwrite"`
+	// MappingsFile is the path to a YAML or JSON file with additional mappings, loaded when the processor starts.
+	// Mapping takes precedence over the mappings from the file.
+	MappingsFile string `mapstructure:"mappings_file"`
+	// Watch defines whether the mappings file should be reloaded when it changes.
+	Watch bool `mapstructure:"watch"`
+	// Rules rename attributes whose names are not in the mappings. The first matching rule is used.
+	Rules []AttributeNameRule `mapstructure:"rules"`
+	// WildcardOptions change how the patterns of Rules are matched.
+	WildcardOptions `mapstructure:",squash"`
+}
+
+// AttributeNameRule renames attributes whose names match a pattern.
+type AttributeNameRule struct {
+	// Pattern is a wildcard pattern of attribute names, see compileWildcards.
+	Pattern string `mapstructure:"pattern"`
+	// Replacement replaces the matched part of the name. Each `*` is replaced with the text matched by the corresponding `*` in Pattern.
+	Replacement string `mapstructure:"replacement"`
+}
+
+// renameAttributesProcessor renames attributes according to a user-provided mapping and rules.
+type renameAttributesProcessor struct {
+	enabled   bool
+	overwrite bool
+	rules     []*wildcardRule
+	// maxKeyLength is the length of keys above which rules are not matched.
+	maxKeyLength int
+	// configMapping is the mapping from the configuration, without the mappings file.
+	configMapping map[string]string
+	mappingsFile  *mappingsFile
+	// mapping holds the current *renameMapping. It is replaced when the mappings file is reloaded.
+	mapping atomic.Value
+}
+
+// renameMapping is a mapping of old attribute names to new attribute names.
+type renameMapping struct {
+	// oldNames holds the keys of mapping in sorted order, so that renaming is deterministic.
+	oldNames []string
+	mapping  map[string]string
+}
+
+func newRenameAttributesProcessor(config *RenameAttributesConfig, logger *zap.Logger) (*renameAttributesProcessor, error) {
+	rules := make([]*wildcardRule, 0, len(config.Rules))
+	for i, rule := range config.Rules {
+		compiled, err := newWildcardRule(rule.Pattern, rule.Replacement, config.WildcardOptions)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		rules = append(rules, compiled)
+	}
+
+	proc := &renameAttributesProcessor{
+		enabled:       config.Enabled,
+		overwrite:     config.Overwrite,
+		rules:         rules,
+		configMapping: config.Mapping,
+		mappingsFile:  newMappingsFile(config.MappingsFile, config.Watch, logger),
+	}
+	proc.setFileMapping(nil)
+
+	return proc, nil
+}
+
+func validateRenameAttributesConfig(config *RenameAttributesConfig) error {
+	if config.Watch && config.MappingsFile == "" {
+		return errors.New("watch requires mappings_file")
+	}
+	for i, rule := range config.Rules {
+		if _, err := newWildcardRule(rule.Pattern, rule.Replacement, config.WildcardOptions); err != nil {
+			return fmt.Errorf("rule %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// setFileMapping combines the mapping from the mappings file with the mapping from the configuration.
+func (proc *renameAttributesProcessor) setFileMapping(fileMapping map[string]string) {
+	mapping := make(map[string]string, len(fileMapping)+len(proc.configMapping))
+	for oldName, newName := range fileMapping {
+		mapping[oldName] = newName
+	}
+	for oldName, newName := range proc.configMapping {
+		mapping[oldName] = newName
+	}
+
+	oldNames := make([]string, 0, len(mapping))
+	for oldName, newName := range mapping {
+		if oldName != newName {
+			oldNames = append(oldNames, oldName)
+		}
+	}
+	sort.Strings(oldNames)
+
+	proc.mapping.Store(&renameMapping{
+		oldNames: oldNames,
+		mapping:  mapping,
+	})
+}
+
+func (proc *renameAttributesProcessor) Start(_ context.Context, _ component.Host) error {
+	if proc.mappingsFile == nil {
+		return nil
+	}
+	return proc.mappingsFile.start(proc.setFileMapping)
+}
+
+func (proc *renameAttributesProcessor) Shutdown(_ context.Context) error {
+	if proc.mappingsFile != nil {
+		proc.mappingsFile.shutdown()
+	}
+	return nil
+}
+
+func (proc *renameAttributesProcessor) ProcessLogs(logs plog.Logs) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnLogs(proc, logs)
+	}
+	return nil
+}
+
+func (proc *renameAttributesProcessor) ProcessMetrics(metrics pmetric.Metrics) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnMetrics(proc, metrics)
+	}
+	return nil
+}
+
+func (proc *renameAttributesProcessor) ProcessTraces(traces ptrace.Traces) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnTraces(proc, traces)
+	}
+	return nil
+}
+
+func (proc *renameAttributesProcessor) IsEnabled() bool {
+	return proc.enabled
+}
+
+func (*renameAttributesProcessor) ConfigPropertyName() string {
+	return "rename_attributes"
+}
+
+func (proc *renameAttributesProcessor) setMaxKeyLength(maxKeyLength int) {
+	proc.maxKeyLength = maxKeyLength
+}
+
+// processAttributes renames the attributes in the mapping, and the other attributes matching a rule.
+// It reads all renamed values before writing any of them,
+// so that renames don't chain (with `a` to `b` and `b` to `c`, `a` becomes `b`)
+// and swapping two attributes doesn't lose any value.
+// As it only moves values between keys, the number of removed attributes is the decrease of the map length.
+func (proc *renameAttributesProcessor) processAttributes(attributes pcommon.Map) int {
+	mapping := proc.mapping.Load().(*renameMapping)
+	before := attributes.Len()
+
+	type rename struct {
+		oldName string
+		newName string
+		value   pcommon.Value
+	}
+	candidates := []rename{}
+	for _, oldName := range mapping.oldNames {
+		if _, found := attributes.Get(oldName); found {
+			candidates = append(candidates, rename{oldName: oldName, newName: mapping.mapping[oldName]})
+		}
+	}
+	if len(proc.rules) > 0 {
+		attributes.Range(func(oldName string, _ pcommon.Value) bool {
+			if _, mapped := mapping.mapping[oldName]; !mapped {
+				if newName, ok := proc.ruleNewName(oldName); ok {
+					candidates = append(candidates, rename{oldName: oldName, newName: newName})
+				}
+			}
+			return true
+		})
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].oldName < candidates[j].oldName
+		})
+	}
+
+	renames := []rename{}
+	newNames := map[string]struct{}{}
+
+	for _, candidate := range candidates {
+		value, _ := attributes.Get(candidate.oldName)
+		oldName, newName := candidate.oldName, candidate.newName
+		_, exists := attributes.Get(newName)
+		_, taken := newNames[newName]
+		if (exists || taken) && !proc.overwrite {
+			continue
+		}
+
+		copied := pcommon.NewValueEmpty()
+		value.CopyTo(copied)
+		renames = append(renames, rename{oldName: oldName, newName: newName, value: copied})
+		newNames[newName] = struct{}{}
+	}
+
+	for _, r := range renames {
+		attributes.Remove(r.oldName)
+	}
+	for _, r := range renames {
+		attributes.Upsert(r.newName, r.value)
+	}
+	return before - attributes.Len()
+}
+
+// ruleNewName returns the new name given by the first rule matching the name.
+func (proc *renameAttributesProcessor) ruleNewName(name string) (string, bool) {
+	if exceedsMaxKeyLength(name, proc.maxKeyLength) {
+		return "", false
+	}
+	for _, rule := range proc.rules {
+		if newName, ok := rule.replace(name); ok {
+			return newName, newName != name
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,290 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+)
+
+func TestRenameAttributes(t *testing.T) {
+	testCases := []struct {
+		name      string
+		mapping   map[string]string
+		overwrite bool
+		input     map[string]interface{}
+		expected  map[string]interface{}
+	}{
+		{
+			name:    "renames attribute preserving value type",
+			mapping: map[string]string{"host": "host.name", "port": "net.host.port"},
+			input: map[string]interface{}{
+				"host":  "my-host",
+				"port":  int64(8080),
+				"other": true,
+			},
+			expected: map[string]interface{}{
+				"host.name":     "my-host",
+				"net.host.port": int64(8080),
+				"other":         true,
+			},
+		},
+		{
+			name:    "does nothing when attribute does not exist",
+			mapping: map[string]string{"host": "host.name"},
+			input: map[string]interface{}{
+				"other": "value",
+			},
+			expected: map[string]interface{}{
+				"other": "value",
+			},
+		},
+		{
+			name:    "skips renaming when target exists",
+			mapping: map[string]string{"host": "host.name"},
+			input: map[string]interface{}{
+				"host":      "old",
+				"host.name": "new",
+			},
+			expected: map[string]interface{}{
+				"host":      "old",
+				"host.name": "new",
+			},
+		},
+		{
+			name:      "overwrites target when configured",
+			mapping:   map[string]string{"host": "host.name"},
+			overwrite: true,
+			input: map[string]interface{}{
+				"host":      "old",
+				"host.name": "new",
+			},
+			expected: map[string]interface{}{
+				"host.name": "old",
+			},
+		},
+		{
+			name:    "does not chain renames",
+			mapping: map[string]string{"a": "b", "b": "c"},
+			input: map[string]interface{}{
+				"a": "1",
+			},
+			expected: map[string]interface{}{
+				"b": "1",
+			},
+		},
+		{
+			name:      "does not chain renames when overwriting",
+			mapping:   map[string]string{"a": "b", "b": "c"},
+			overwrite: true,
+			input: map[string]interface{}{
+				"a": "1",
+				"b": "2",
+			},
+			expected: map[string]interface{}{
+				"b": "1",
+				"c": "2",
+			},
+		},
+		{
+			name:      "swaps attributes when overwriting",
+			mapping:   map[string]string{"a": "b", "b": "a"},
+			overwrite: true,
+			input: map[string]interface{}{
+				"a": "1",
+				"b": int64(2),
+			},
+			expected: map[string]interface{}{
+				"a": int64(2),
+				"b": "1",
+			},
+		},
+		{
+			name:    "does not swap attributes without overwrite",
+			mapping: map[string]string{"a": "b", "b": "a"},
+			input: map[string]interface{}{
+				"a": "1",
+				"b": "2",
+			},
+			expected: map[string]interface{}{
+				"a": "1",
+				"b": "2",
+			},
+		},
+		{
+			name:    "first rename in key order wins a shared target",
+			mapping: map[string]string{"a": "x", "b": "x"},
+			input: map[string]interface{}{
+				"a": "1",
+				"b": "2",
+			},
+			expected: map[string]interface{}{
+				"x": "1",
+				"b": "2",
+			},
+		},
+		{
+			name:      "does nothing when old and new names are equal",
+			mapping:   map[string]string{"host": "host"},
+			overwrite: true,
+			input: map[string]interface{}{
+				"host": "my-host",
+			},
+			expected: map[string]interface{}{
+				"host": "my-host",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			processor, err := newRenameAttributesProcessor(&RenameAttributesConfig{
+				Enabled:   true,
+				Mapping:   testCase.mapping,
+				Overwrite: testCase.overwrite,
+			}, zap.NewNop())
+			require.NoError(t, err)
+
+			attributes := pcommon.NewMapFromRaw(testCase.input)
+			processor.processAttributes(attributes)
+
+			assert.Equal(t, testCase.expected, attributes.AsRaw())
+		})
+	}
+}
+
+func TestRenameAttributesRules(t *testing.T) {
+	processor, err := newRenameAttributesProcessor(&RenameAttributesConfig{
+		Enabled: true,
+		Mapping: map[string]string{"pod_id": "k8s.pod.uid", "pod_ip": "pod_ip"},
+		Rules: []AttributeNameRule{
+			{Pattern: "pod_*", Replacement: "k8s.pod.*"},
+			{Pattern: `rate\*`, Replacement: "rate"},
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	attributes := pcommon.NewMapFromRaw(map[string]interface{}{
+		"pod_id":   "1",
+		"pod_ip":   "10.0.0.1",
+		"pod_name": "my-pod",
+		"rate*":    int64(5),
+		"rates":    int64(6),
+	})
+	processor.processAttributes(attributes)
+
+	assert.Equal(t, map[string]interface{}{
+		"k8s.pod.uid":  "1",
+		"pod_ip":       "10.0.0.1",
+		"k8s.pod.name": "my-pod",
+		"rate":         int64(5),
+		"rates":        int64(6),
+	}, attributes.AsRaw())
+}
+
+func TestRenameAttributesRulesWildcardOptions(t *testing.T) {
+	processor, err := newRenameAttributesProcessor(&RenameAttributesConfig{
+		Enabled:         true,
+		Rules:           []AttributeNameRule{{Pattern: "pod_", Replacement: "k8s.pod."}},
+		WildcardOptions: WildcardOptions{Unanchored: true, CaseInsensitive: true},
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	attributes := pcommon.NewMapFromRaw(map[string]interface{}{
+		"my_POD_name": "my-pod",
+		"host":        "my-host",
+	})
+	processor.processAttributes(attributes)
+
+	assert.Equal(t, map[string]interface{}{
+		"my_k8s.pod.name": "my-pod",
+		"host":            "my-host",
+	}, attributes.AsRaw())
+}
+
+func TestRenameAttributesRulesTargetConflict(t *testing.T) {
+	processor, err := newRenameAttributesProcessor(&RenameAttributesConfig{
+		Enabled: true,
+		Mapping: map[string]string{"b_name": "name"},
+		Rules:   []AttributeNameRule{{Pattern: "*_name", Replacement: "name"}},
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	attributes := pcommon.NewMapFromRaw(map[string]interface{}{
+		"a_name": "a",
+		"b_name": "b",
+	})
+	processor.processAttributes(attributes)
+
+	// The first rename in key order wins, regardless of whether it comes from the mapping or a rule.
+	assert.Equal(t, map[string]interface{}{
+		"name":   "a",
+		"b_name": "b",
+	}, attributes.AsRaw())
+}
+
+func TestRenameAttributesInvalidRule(t *testing.T) {
+	_, err := newRenameAttributesProcessor(&RenameAttributesConfig{
+		Enabled: true,
+		Rules:   []AttributeNameRule{{Pattern: "pod_*", Replacement: "*_*"}},
+	}, zap.NewNop())
+	assert.EqualError(t, err, "rule 0: replacement has more `*` characters than pattern")
+}
+
+func TestRenameAttributesMappingsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mappings.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("pod: k8s.pod.name\nnode: node_from_file\n"), 0600))
+
+	processor, err := newRenameAttributesProcessor(&RenameAttributesConfig{
+		Enabled:      true,
+		Mapping:      map[string]string{"node": "k8s.node.name"},
+		MappingsFile: path,
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, processor.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, processor.Shutdown(context.Background()))
+	}()
+
+	attributes := pcommon.NewMapFromRaw(map[string]interface{}{
+		"pod":  "my-pod",
+		"node": "my-node",
+	})
+	processor.processAttributes(attributes)
+
+	assert.Equal(t, map[string]interface{}{
+		"k8s.pod.name":  "my-pod",
+		"k8s.node.name": "my-node",
+	}, attributes.AsRaw())
+}
+
+func TestRenameAttributesInvalidMappingsFile(t *testing.T) {
+	processor, err := newRenameAttributesProcessor(&RenameAttributesConfig{
+		Enabled:      true,
+		MappingsFile: filepath.Join(t.TempDir(), "missing.yaml"),
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	err = processor.Start(context.Background(), componenttest.NewNopHost())
+	assert.ErrorContains(t, err, "failed to read mappings file")
+}
@@ -0,0 +1,156 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// RewriteKeysConfig configures the rewrite_keys sub-processor.
+type RewriteKeysConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Pattern is a regular expression replaced in all attribute keys.
+	Pattern string `mapstructure:"pattern"`
+	// Replacement replaces every match of Pattern. It can refer to capture groups with `$1` or `${name}`.
+	Replacement string `mapstructure:"replacement"`
+	// Overwrite defines whether an attribute which already has the rewritten key should be overwritten.
+	Overwrite bool `mapstructure:"overwrite"`
+}
+
+// rewriteKeysProcessor replaces matches of a regular expression in all attribute keys.
+type rewriteKeysProcessor struct {
+	enabled     bool
+	regex       *regexp.Regexp
+	replacement string
+	overwrite   bool
+	// maxKeyLength is the length of keys above which the regular expression is not matched.
+	maxKeyLength int
+}
+
+func newRewriteKeysProcessor(config *RewriteKeysConfig) (*rewriteKeysProcessor, error) {
+	if !config.Enabled {
+		return &rewriteKeysProcessor{enabled: false}, nil
+	}
+
+	if err := validateRewriteKeysConfig(config); err != nil {
+		return nil, err
+	}
+
+	return &rewriteKeysProcessor{
+		enabled:     true,
+		regex:       regexp.MustCompile(config.Pattern),
+		replacement: config.Replacement,
+		overwrite:   config.Overwrite,
+	}, nil
+}
+
+func validateRewriteKeysConfig(config *RewriteKeysConfig) error {
+	if config.Pattern == "" {
+		return errors.New("pattern must not be empty")
+	}
+
+	if _, err := regexp.Compile(config.Pattern); err != nil {
+		return fmt.Errorf("invalid pattern %q: %w", config.Pattern, err)
+	}
+
+	return nil
+}
+
+func (proc *rewriteKeysProcessor) ProcessLogs(logs plog.Logs) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnLogs(proc, logs)
+	}
+	return nil
+}
+
+func (proc *rewriteKeysProcessor) ProcessMetrics(metrics pmetric.Metrics) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnMetrics(proc, metrics)
+	}
+	return nil
+}
+
+func (proc *rewriteKeysProcessor) ProcessTraces(traces ptrace.Traces) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnTraces(proc, traces)
+	}
+	return nil
+}
+
+func (proc *rewriteKeysProcessor) IsEnabled() bool {
+	return proc.enabled
+}
+
+func (*rewriteKeysProcessor) ConfigPropertyName() string {
+	return "rewrite_keys"
+}
+
+func (proc *rewriteKeysProcessor) setMaxKeyLength(maxKeyLength int) {
+	proc.maxKeyLength = maxKeyLength
+}
+
+// processAttributes rewrites attribute keys in the order in which they were added to the map.
+// Keys which would be rewritten to an empty key are left unchanged.
+// Like rename_attributes, it reads all rewritten values before writing any of them,
+// so that a value written to a new key is never rewritten again.
+// As it only moves values between keys, the number of removed attributes is the decrease of the map length.
+func (proc *rewriteKeysProcessor) processAttributes(attributes pcommon.Map) int {
+	before := attributes.Len()
+
+	type rewrite struct {
+		key    string
+		newKey string
+		value  pcommon.Value
+	}
+	rewrites := []rewrite{}
+	newKeys := map[string]struct{}{}
+
+	attributes.Range(func(key string, value pcommon.Value) bool {
+		if exceedsMaxKeyLength(key, proc.maxKeyLength) {
+			return true
+		}
+		newKey := proc.regex.ReplaceAllString(key, proc.replacement)
+		if newKey == key || newKey == "" {
+			return true
+		}
+
+		_, exists := attributes.Get(newKey)
+		_, taken := newKeys[newKey]
+		if (exists || taken) && !proc.overwrite {
+			return true
+		}
+
+		copied := pcommon.NewValueEmpty()
+		value.CopyTo(copied)
+		rewrites = append(rewrites, rewrite{key: key, newKey: newKey, value: copied})
+		newKeys[newKey] = struct{}{}
+		return true
+	})
+
+	for _, r := range rewrites {
+		attributes.Remove(r.key)
+	}
+	for _, r := range rewrites {
+		attributes.Upsert(r.newKey, r.value)
+	}
+	return before - attributes.Len()
+}
@@ -0,0 +1,189 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestRewriteKeys(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   RewriteKeysConfig
+		input    [][2]string
+		expected map[string]interface{}
+	}{
+		{
+			name:   "replaces all matches",
+			config: RewriteKeysConfig{Pattern: "__", Replacement: "."},
+			input: [][2]string{
+				{"k8s__pod__name", "pod"},
+				{"host", "host"},
+			},
+			expected: map[string]interface{}{
+				"k8s.pod.name": "pod",
+				"host":         "host",
+			},
+		},
+		{
+			name:   "replaces group references",
+			config: RewriteKeysConfig{Pattern: `^label_(\w+)_(\w+)$`, Replacement: "$2.${1}"},
+			input: [][2]string{
+				{"label_app_name", "shop"},
+				{"label", "other"},
+			},
+			expected: map[string]interface{}{
+				"name.app": "shop",
+				"label":    "other",
+			},
+		},
+		{
+			name:   "leaves keys rewritten to empty key unchanged",
+			config: RewriteKeysConfig{Pattern: "^tmp_.*", Replacement: ""},
+			input: [][2]string{
+				{"tmp_value", "value"},
+			},
+			expected: map[string]interface{}{
+				"tmp_value": "value",
+			},
+		},
+		{
+			name:   "skips colliding keys",
+			config: RewriteKeysConfig{Pattern: "[_-]", Replacement: "."},
+			input: [][2]string{
+				{"pod.name", "first"},
+				{"pod_name", "second"},
+				{"pod-name", "third"},
+			},
+			expected: map[string]interface{}{
+				"pod.name": "first",
+				"pod_name": "second",
+				"pod-name": "third",
+			},
+		},
+		{
+			name:   "overwrites colliding keys",
+			config: RewriteKeysConfig{Pattern: "[_-]", Replacement: ".", Overwrite: true},
+			input: [][2]string{
+				{"pod.name", "first"},
+				{"pod_name", "second"},
+				{"pod-name", "third"},
+			},
+			expected: map[string]interface{}{
+				"pod.name": "third",
+			},
+		},
+		{
+			name:   "does not rewrite rewritten keys again with overwrite",
+			config: RewriteKeysConfig{Pattern: "_", Replacement: "__", Overwrite: true},
+			input: [][2]string{
+				{"a_b", "v1"},
+				{"a__b", "v2"},
+			},
+			expected: map[string]interface{}{
+				"a__b":   "v1",
+				"a____b": "v2",
+			},
+		},
+		{
+			name:   "does not rewrite rewritten keys again with skip",
+			config: RewriteKeysConfig{Pattern: "_", Replacement: "__"},
+			input: [][2]string{
+				{"a_b", "v1"},
+				{"a__b", "v2"},
+			},
+			expected: map[string]interface{}{
+				"a_b":    "v1",
+				"a____b": "v2",
+			},
+		},
+		{
+			name:   "skip does not depend on insertion order",
+			config: RewriteKeysConfig{Pattern: "_", Replacement: "__"},
+			input: [][2]string{
+				{"a__b", "v2"},
+				{"a_b", "v1"},
+			},
+			expected: map[string]interface{}{
+				"a_b":    "v1",
+				"a____b": "v2",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.config.Enabled = true
+			processor, err := newRewriteKeysProcessor(&testCase.config)
+			require.NoError(t, err)
+
+			// Insert in a fixed order, as the conflict resolution depends on it.
+			attributes := pcommon.NewMap()
+			for _, keyValue := range testCase.input {
+				attributes.InsertString(keyValue[0], keyValue[1])
+			}
+			processor.processAttributes(attributes)
+
+			assert.Equal(t, testCase.expected, attributes.AsRaw())
+		})
+	}
+}
+
+func TestRewriteKeysAllSignals(t *testing.T) {
+	processor, err := newRewriteKeysProcessor(&RewriteKeysConfig{
+		Enabled:     true,
+		Pattern:     "__",
+		Replacement: ".",
+	})
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	resourceLogs.Resource().Attributes().InsertString("k8s__namespace", "ns")
+	logRecord := resourceLogs.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	logRecord.Attributes().InsertString("http__method", "GET")
+	require.NoError(t, processor.ProcessLogs(logs))
+	assert.Equal(t, map[string]interface{}{"k8s.namespace": "ns"}, resourceLogs.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"http.method": "GET"}, logRecord.Attributes().AsRaw())
+
+	metrics := pmetric.NewMetrics()
+	metric := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetDataType(pmetric.MetricDataTypeGauge)
+	dataPoint := metric.Gauge().DataPoints().AppendEmpty()
+	dataPoint.Attributes().InsertString("http__method", "GET")
+	require.NoError(t, processor.ProcessMetrics(metrics))
+	assert.Equal(t, map[string]interface{}{"http.method": "GET"}, dataPoint.Attributes().AsRaw())
+
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.Attributes().InsertString("http__method", "GET")
+	require.NoError(t, processor.ProcessTraces(traces))
+	assert.Equal(t, map[string]interface{}{"http.method": "GET"}, span.Attributes().AsRaw())
+}
+
+func TestRewriteKeysInvalidConfig(t *testing.T) {
+	_, err := newRewriteKeysProcessor(&RewriteKeysConfig{Enabled: true})
+	assert.EqualError(t, err, "pattern must not be empty")
+
+	_, err = newRewriteKeysProcessor(&RewriteKeysConfig{Enabled: true, Pattern: "("})
+	assert.EqualError(t, err, "invalid pattern \"(\": error parsing regexp: missing closing ): `(`")
+}
@@ -0,0 +1,150 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	sampleMissingKeep = "keep"
+	sampleMissingDrop = "drop"
+)
+
+// SampleByAttributeConfig configures the sample_by_attribute sub-processor.
+type SampleByAttributeConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Key is the key of the log record attribute the sampling decision is based on.
+	Key string `mapstructure:"key"`
+	// Ratio is the fraction of attribute values whose log records are kept, between 0 and 1.
+	Ratio float64 `mapstructure:"ratio"`
+	// Missing defines what happens with log records without the attribute, either `keep` or `drop`.
+	Missing string `mapstructure:"missing"`
+}
+
+// sampleByAttributeProcessor drops log records based on a hash of an attribute value,
+// so that log records with the same value are either all kept or all dropped.
+type sampleByAttributeProcessor struct {
+	enabled     bool
+	key         string
+	keepAll     bool
+	threshold   uint64
+	keepMissing bool
+}
+
+func newSampleByAttributeProcessor(config *SampleByAttributeConfig) (*sampleByAttributeProcessor, error) {
+	if config.Enabled {
+		if err := validateSampleByAttributeConfig(config); err != nil {
+			return nil, err
+		}
+	}
+
+	return &sampleByAttributeProcessor{
+		enabled:     config.Enabled,
+		key:         config.Key,
+		keepAll:     config.Ratio >= 1,
+		threshold:   uint64(config.Ratio * math.MaxUint64),
+		keepMissing: config.Missing == sampleMissingKeep,
+	}, nil
+}
+
+func validateSampleByAttributeConfig(config *SampleByAttributeConfig) error {
+	if config.Key == "" {
+		return errors.New("key must not be empty")
+	}
+
+	if config.Ratio < 0 || config.Ratio > 1 || math.IsNaN(config.Ratio) {
+		return fmt.Errorf("ratio must be between 0 and 1, got %v", config.Ratio)
+	}
+
+	switch config.Missing {
+	case sampleMissingKeep, sampleMissingDrop:
+		return nil
+	default:
+		return fmt.Errorf("invalid missing: %q", config.Missing)
+	}
+}
+
+func (proc *sampleByAttributeProcessor) ProcessLogs(logs plog.Logs) error {
+	if !proc.enabled {
+		return nil
+	}
+
+	// Empty scopes and resources are kept, so that the structure of the data does not change.
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		scopeLogs := logs.ResourceLogs().At(i).ScopeLogs()
+
+		for j := 0; j < scopeLogs.Len(); j++ {
+			scopeLogs.At(j).LogRecords().RemoveIf(func(logRecord plog.LogRecord) bool {
+				return !proc.keep(logRecord)
+			})
+		}
+	}
+	return nil
+}
+
+func (proc *sampleByAttributeProcessor) ProcessMetrics(_ pmetric.Metrics) error {
+	// No-op, this subprocessor doesn't process metrics.
+	return nil
+}
+
+func (proc *sampleByAttributeProcessor) ProcessTraces(_ ptrace.Traces) error {
+	// No-op, this subprocessor doesn't process traces.
+	return nil
+}
+
+func (proc *sampleByAttributeProcessor) IsEnabled() bool {
+	return proc.enabled
+}
+
+func (*sampleByAttributeProcessor) ConfigPropertyName() string {
+	return "sample_by_attribute"
+}
+
+func (*sampleByAttributeProcessor) removesRecords() {}
+
+// keep returns true if the log record should be kept.
+func (proc *sampleByAttributeProcessor) keep(logRecord plog.LogRecord) bool {
+	value, found := logRecord.Attributes().Get(proc.key)
+	if !found {
+		return proc.keepMissing
+	}
+	if proc.keepAll {
+		return true
+	}
+
+	hash := fnv.New64a()
+	// Writing to a hash never fails.
+	_, _ = hash.Write([]byte(value.AsString()))
+	return mix64(hash.Sum64()) < proc.threshold
+}
+
+// mix64 spreads the bits of the hash over the whole range, as the high bits of FNV hashes of short,
+// similar values like `id-1` and `id-2` hardly differ. It is the finalizer of MurmurHash3.
+func mix64(hash uint64) uint64 {
+	hash ^= hash >> 33
+	hash *= 0xff51afd7ed558ccd
+	hash ^= hash >> 33
+	hash *= 0xc4ceb9fe1a85ec53
+	hash ^= hash >> 33
+	return hash
+}
@@ -0,0 +1,145 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// newSampledLogs returns logs with 10 log records for each of 100 trace IDs, spread over two scopes.
+func newSampledLogs() plog.Logs {
+	logs := plog.NewLogs()
+	scopeLogs := logs.ResourceLogs().AppendEmpty().ScopeLogs()
+	for _, logRecords := range []plog.LogRecordSlice{scopeLogs.AppendEmpty().LogRecords(), scopeLogs.AppendEmpty().LogRecords()} {
+		for i := 0; i < 5; i++ {
+			for id := 0; id < 100; id++ {
+				logRecords.AppendEmpty().Attributes().InsertString("trace_id", fmt.Sprintf("id-%d", id))
+			}
+		}
+	}
+	return logs
+}
+
+// keptTraceIDs returns the number of kept log records for each trace ID.
+func keptTraceIDs(logs plog.Logs) map[string]int {
+	kept := map[string]int{}
+	processLogRecords(logs, func(logRecord plog.LogRecord) {
+		traceID, _ := logRecord.Attributes().Get("trace_id")
+		kept[traceID.StringVal()]++
+	})
+	return kept
+}
+
+func TestSampleByAttribute(t *testing.T) {
+	processor, err := newSampleByAttributeProcessor(&SampleByAttributeConfig{
+		Enabled: true,
+		Key:     "trace_id",
+		Ratio:   0.5,
+		Missing: sampleMissingKeep,
+	})
+	require.NoError(t, err)
+
+	logs := newSampledLogs()
+	require.NoError(t, processor.ProcessLogs(logs))
+	kept := keptTraceIDs(logs)
+
+	// All log records with the same trace ID are either kept or dropped.
+	for traceID, count := range kept {
+		assert.Equal(t, 10, count, traceID)
+	}
+	assert.InDelta(t, 50, len(kept), 15)
+
+	// The decision is deterministic.
+	otherLogs := newSampledLogs()
+	require.NoError(t, processor.ProcessLogs(otherLogs))
+	assert.Equal(t, kept, keptTraceIDs(otherLogs))
+
+	// Empty scopes and resources are kept.
+	assert.Equal(t, 1, logs.ResourceLogs().Len())
+	assert.Equal(t, 2, logs.ResourceLogs().At(0).ScopeLogs().Len())
+}
+
+func TestSampleByAttributeRatio(t *testing.T) {
+	for _, ratio := range []float64{0, 1} {
+		t.Run(fmt.Sprint(ratio), func(t *testing.T) {
+			processor, err := newSampleByAttributeProcessor(&SampleByAttributeConfig{
+				Enabled: true,
+				Key:     "trace_id",
+				Ratio:   ratio,
+				Missing: sampleMissingKeep,
+			})
+			require.NoError(t, err)
+
+			logs := newSampledLogs()
+			require.NoError(t, processor.ProcessLogs(logs))
+			assert.Equal(t, int(ratio*1000), logs.LogRecordCount())
+		})
+	}
+}
+
+func TestSampleByAttributeMissing(t *testing.T) {
+	testCases := []struct {
+		missing  string
+		expected int
+	}{
+		{missing: sampleMissingKeep, expected: 1},
+		{missing: sampleMissingDrop, expected: 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.missing, func(t *testing.T) {
+			processor, err := newSampleByAttributeProcessor(&SampleByAttributeConfig{
+				Enabled: true,
+				Key:     "trace_id",
+				Ratio:   1,
+				Missing: testCase.missing,
+			})
+			require.NoError(t, err)
+
+			logs := plog.NewLogs()
+			logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().InsertString("span_id", "a")
+			require.NoError(t, processor.ProcessLogs(logs))
+			assert.Equal(t, testCase.expected, logs.LogRecordCount())
+		})
+	}
+}
+
+func TestSampleByAttributeInvalidConfig(t *testing.T) {
+	_, err := newSampleByAttributeProcessor(&SampleByAttributeConfig{Enabled: true, Ratio: 0.5, Missing: sampleMissingKeep})
+	assert.EqualError(t, err, "key must not be empty")
+
+	_, err = newSampleByAttributeProcessor(&SampleByAttributeConfig{Enabled: true, Key: "trace_id", Ratio: 1.5, Missing: sampleMissingKeep})
+	assert.EqualError(t, err, "ratio must be between 0 and 1, got 1.5")
+
+	_, err = newSampleByAttributeProcessor(&SampleByAttributeConfig{Enabled: true, Key: "trace_id", Ratio: 0.5, Missing: "sample"})
+	assert.EqualError(t, err, `invalid missing: "sample"`)
+}
+
+func TestSampleByAttributeCondition(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.SampleByAttribute.Enabled = true
+	config.SampleByAttribute.Key = "trace_id"
+	config.Conditions = map[string]ConditionConfig{
+		"sample_by_attribute": {Attribute: "source", Value: "k8s"},
+	}
+
+	_, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), config)
+	assert.EqualError(t, err, "conditions: sample_by_attribute: sub-processors which remove records cannot have a condition")
+}
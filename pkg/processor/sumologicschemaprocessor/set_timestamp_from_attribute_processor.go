@@ -0,0 +1,178 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const (
+	timestampLayoutEpochSeconds      = "epoch_s"
+	timestampLayoutEpochMilliseconds = "epoch_ms"
+)
+
+// SetTimestampFromAttributeConfig configures the set_timestamp_from_attribute sub-processor.
+type SetTimestampFromAttributeConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Attribute is the key of the log record attribute containing the timestamp.
+	Attribute string `mapstructure:"attribute"`
+	// Layouts are Go time layouts, `epoch_s` or `epoch_ms`. They are tried in order.
+	// If empty, RFC 3339 is used.
+	Layouts []string `mapstructure:"layouts"`
+}
+
+// setTimestampFromAttributeProcessor sets the timestamp of log records from one of their attributes.
+type setTimestampFromAttributeProcessor struct {
+	logger    *zap.Logger
+	enabled   bool
+	attribute string
+	layouts   []string
+}
+
+func newSetTimestampFromAttributeProcessor(config *SetTimestampFromAttributeConfig, logger *zap.Logger) (*setTimestampFromAttributeProcessor, error) {
+	if config.Enabled {
+		if err := validateSetTimestampFromAttributeConfig(config); err != nil {
+			return nil, err
+		}
+	}
+
+	layouts := config.Layouts
+	if len(layouts) == 0 {
+		layouts = []string{time.RFC3339Nano}
+	}
+
+	return &setTimestampFromAttributeProcessor{
+		logger:    logger,
+		enabled:   config.Enabled,
+		attribute: config.Attribute,
+		layouts:   layouts,
+	}, nil
+}
+
+func validateSetTimestampFromAttributeConfig(config *SetTimestampFromAttributeConfig) error {
+	if config.Attribute == "" {
+		return errors.New("attribute must not be empty")
+	}
+
+	for i, layout := range config.Layouts {
+		if layout == "" {
+			return fmt.Errorf("layout %d must not be empty", i)
+		}
+	}
+
+	return nil
+}
+
+func (proc *setTimestampFromAttributeProcessor) ProcessLogs(logs plog.Logs) error {
+	if proc.enabled {
+		processLogRecords(logs, proc.setTimestamp)
+	}
+	return nil
+}
+
+func (proc *setTimestampFromAttributeProcessor) ProcessMetrics(_ pmetric.Metrics) error {
+	// No-op, this subprocessor doesn't process metrics.
+	return nil
+}
+
+func (proc *setTimestampFromAttributeProcessor) ProcessTraces(_ ptrace.Traces) error {
+	// No-op, this subprocessor doesn't process traces.
+	return nil
+}
+
+func (proc *setTimestampFromAttributeProcessor) IsEnabled() bool {
+	return proc.enabled
+}
+
+func (*setTimestampFromAttributeProcessor) ConfigPropertyName() string {
+	return "set_timestamp_from_attribute"
+}
+
+func (proc *setTimestampFromAttributeProcessor) setTimestamp(logRecord plog.LogRecord) {
+	value, found := logRecord.Attributes().Get(proc.attribute)
+	if !found {
+		return
+	}
+
+	for _, layout := range proc.layouts {
+		if timestamp, ok := parseTimestamp(value, layout); ok {
+			logRecord.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+			return
+		}
+	}
+
+	proc.logger.Debug("Failed to parse timestamp attribute",
+		zap.String("attribute", proc.attribute),
+		zap.String("value", value.AsString()),
+		zap.Strings("layouts", proc.layouts),
+	)
+}
+
+// parseTimestamp parses the value using the layout. Epoch layouts accept numbers and numeric strings,
+// other layouts accept strings only.
+func parseTimestamp(value pcommon.Value, layout string) (time.Time, bool) {
+	switch layout {
+	case timestampLayoutEpochSeconds:
+		seconds, ok := epochValue(value)
+		if !ok {
+			return time.Time{}, false
+		}
+		whole, fraction := math.Modf(seconds)
+		return time.Unix(int64(whole), int64(fraction*float64(time.Second))), true
+	case timestampLayoutEpochMilliseconds:
+		milliseconds, ok := epochValue(value)
+		if !ok {
+			return time.Time{}, false
+		}
+		return time.UnixMilli(int64(milliseconds)), true
+	}
+
+	if value.Type() != pcommon.ValueTypeString {
+		return time.Time{}, false
+	}
+
+	timestamp, err := time.Parse(layout, value.StringVal())
+	if err != nil {
+		return time.Time{}, false
+	}
+	return timestamp, true
+}
+
+func epochValue(value pcommon.Value) (float64, bool) {
+	switch value.Type() {
+	case pcommon.ValueTypeInt:
+		return float64(value.IntVal()), true
+	case pcommon.ValueTypeDouble:
+		return value.DoubleVal(), true
+	case pcommon.ValueTypeString:
+		number, err := strconv.ParseFloat(value.StringVal(), 64)
+		if err != nil {
+			return 0, false
+		}
+		return number, true
+	default:
+		return 0, false
+	}
+}
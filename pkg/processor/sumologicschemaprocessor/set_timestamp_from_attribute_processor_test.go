@@ -0,0 +1,130 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSetTimestampFromAttribute(t *testing.T) {
+	testCases := []struct {
+		name     string
+		layouts  []string
+		value    interface{}
+		expected time.Time
+	}{
+		{
+			name:     "RFC3339 by default",
+			value:    "2022-08-01T12:30:45Z",
+			expected: time.Date(2022, 8, 1, 12, 30, 45, 0, time.UTC),
+		},
+		{
+			name:     "RFC3339 with fraction and offset",
+			value:    "2022-08-01T14:30:45.123+02:00",
+			expected: time.Date(2022, 8, 1, 12, 30, 45, 123000000, time.UTC),
+		},
+		{
+			name:     "custom layout",
+			layouts:  []string{"02/01/2006 15:04:05"},
+			value:    "01/08/2022 12:30:45",
+			expected: time.Date(2022, 8, 1, 12, 30, 45, 0, time.UTC),
+		},
+		{
+			name:     "epoch seconds int",
+			layouts:  []string{"epoch_s"},
+			value:    int64(1659357045),
+			expected: time.Date(2022, 8, 1, 12, 30, 45, 0, time.UTC),
+		},
+		{
+			name:     "epoch seconds double",
+			layouts:  []string{"epoch_s"},
+			value:    1659357045.5,
+			expected: time.Date(2022, 8, 1, 12, 30, 45, 500000000, time.UTC),
+		},
+		{
+			name:     "epoch milliseconds string",
+			layouts:  []string{"epoch_ms"},
+			value:    "1659357045123",
+			expected: time.Date(2022, 8, 1, 12, 30, 45, 123000000, time.UTC),
+		},
+		{
+			name:     "first matching layout",
+			layouts:  []string{time.RFC3339, "epoch_ms"},
+			value:    "1659357045123",
+			expected: time.Date(2022, 8, 1, 12, 30, 45, 123000000, time.UTC),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			processor, err := newSetTimestampFromAttributeProcessor(&SetTimestampFromAttributeConfig{
+				Enabled:   true,
+				Attribute: "time",
+				Layouts:   testCase.layouts,
+			}, zap.NewNop())
+			require.NoError(t, err)
+
+			logs := plog.NewLogs()
+			logRecord := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+			pcommon.NewMapFromRaw(map[string]interface{}{"time": testCase.value}).CopyTo(logRecord.Attributes())
+
+			require.NoError(t, processor.ProcessLogs(logs))
+
+			assert.Equal(t, testCase.expected, logRecord.Timestamp().AsTime())
+		})
+	}
+}
+
+func TestSetTimestampFromAttributeParseFailure(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	processor, err := newSetTimestampFromAttributeProcessor(&SetTimestampFromAttributeConfig{
+		Enabled:   true,
+		Attribute: "time",
+		Layouts:   []string{time.RFC3339, "epoch_s"},
+	}, zap.New(core))
+	require.NoError(t, err)
+
+	original := pcommon.NewTimestampFromTime(time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC))
+	logRecord := plog.NewLogRecord()
+	logRecord.SetTimestamp(original)
+	logRecord.Attributes().InsertString("time", "yesterday")
+
+	processor.setTimestamp(logRecord)
+
+	assert.Equal(t, original, logRecord.Timestamp())
+	assert.Equal(t, 1, logs.FilterMessage("Failed to parse timestamp attribute").Len())
+}
+
+func TestSetTimestampFromAttributeMissingAttribute(t *testing.T) {
+	processor, err := newSetTimestampFromAttributeProcessor(&SetTimestampFromAttributeConfig{
+		Enabled:   true,
+		Attribute: "time",
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	logRecord := plog.NewLogRecord()
+	processor.setTimestamp(logRecord)
+
+	assert.Equal(t, pcommon.Timestamp(0), logRecord.Timestamp())
+}
@@ -0,0 +1,119 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// SplitAttributesConfig configures the split_attributes sub-processor.
+type SplitAttributesConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Attributes are the keys of attributes with delimited values.
+	Attributes []string `mapstructure:"attributes"`
+	// PairSeparator separates key/value pairs, e.g. `,` in `a=1,b=2`.
+	PairSeparator string `mapstructure:"pair_separator"`
+	// KeyValueSeparator separates a key from a value, e.g. `=` in `a=1,b=2`.
+	KeyValueSeparator string `mapstructure:"key_value_separator"`
+}
+
+// splitAttributesProcessor parses delimited string attribute values into maps.
+type splitAttributesProcessor struct {
+	enabled           bool
+	attributes        []string
+	pairSeparator     string
+	keyValueSeparator string
+}
+
+func newSplitAttributesProcessor(config *SplitAttributesConfig) (*splitAttributesProcessor, error) {
+	if err := validateSplitAttributesConfig(config); err != nil {
+		return nil, err
+	}
+
+	return &splitAttributesProcessor{
+		enabled:           config.Enabled,
+		attributes:        config.Attributes,
+		pairSeparator:     config.PairSeparator,
+		keyValueSeparator: config.KeyValueSeparator,
+	}, nil
+}
+
+func validateSplitAttributesConfig(config *SplitAttributesConfig) error {
+	if config.PairSeparator == "" {
+		return errors.New("pair_separator must not be empty")
+	}
+	if config.KeyValueSeparator == "" {
+		return errors.New("key_value_separator must not be empty")
+	}
+	return nil
+}
+
+func (proc *splitAttributesProcessor) ProcessLogs(logs plog.Logs) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnLogs(proc, logs)
+	}
+	return nil
+}
+
+func (proc *splitAttributesProcessor) ProcessMetrics(metrics pmetric.Metrics) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnMetrics(proc, metrics)
+	}
+	return nil
+}
+
+func (proc *splitAttributesProcessor) ProcessTraces(traces ptrace.Traces) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnTraces(proc, traces)
+	}
+	return nil
+}
+
+func (proc *splitAttributesProcessor) IsEnabled() bool {
+	return proc.enabled
+}
+
+func (*splitAttributesProcessor) ConfigPropertyName() string {
+	return "split_attributes"
+}
+
+func (proc *splitAttributesProcessor) processAttributes(attributes pcommon.Map) int {
+	for _, key := range proc.attributes {
+		value, found := attributes.Get(key)
+		if !found || value.Type() != pcommon.ValueTypeString {
+			continue
+		}
+
+		split := pcommon.NewValueMap()
+		splitMap := split.MapVal()
+		for _, pair := range strings.Split(value.StringVal(), proc.pairSeparator) {
+			keyValue := strings.SplitN(pair, proc.keyValueSeparator, 2)
+			// Skip empty segments and segments without a key or a separator.
+			if len(keyValue) != 2 || keyValue[0] == "" {
+				continue
+			}
+			splitMap.UpsertString(keyValue[0], keyValue[1])
+		}
+
+		attributes.Update(key, split)
+	}
+	return 0
+}
@@ -0,0 +1,111 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestSplitAttributes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name: "splits delimited value",
+			input: map[string]interface{}{
+				"labels": "a=1,b=2",
+			},
+			expected: map[string]interface{}{
+				"labels": map[string]interface{}{"a": "1", "b": "2"},
+			},
+		},
+		{
+			name: "handles trailing separators and empty segments",
+			input: map[string]interface{}{
+				"labels": ",a=1,,b=2,",
+			},
+			expected: map[string]interface{}{
+				"labels": map[string]interface{}{"a": "1", "b": "2"},
+			},
+		},
+		{
+			name: "skips malformed pairs",
+			input: map[string]interface{}{
+				"labels": "a=1,b,=3,c=",
+			},
+			expected: map[string]interface{}{
+				"labels": map[string]interface{}{"a": "1", "c": ""},
+			},
+		},
+		{
+			name: "keeps key/value separator in value",
+			input: map[string]interface{}{
+				"labels": "query=a=b",
+			},
+			expected: map[string]interface{}{
+				"labels": map[string]interface{}{"query": "a=b"},
+			},
+		},
+		{
+			name: "does nothing when attribute does not exist",
+			input: map[string]interface{}{
+				"other": "a=1",
+			},
+			expected: map[string]interface{}{
+				"other": "a=1",
+			},
+		},
+		{
+			name: "does nothing for non-string value",
+			input: map[string]interface{}{
+				"labels": int64(1),
+			},
+			expected: map[string]interface{}{
+				"labels": int64(1),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			processor, err := newSplitAttributesProcessor(&SplitAttributesConfig{
+				Enabled:           true,
+				Attributes:        []string{"labels"},
+				PairSeparator:     ",",
+				KeyValueSeparator: "=",
+			})
+			require.NoError(t, err)
+
+			attributes := pcommon.NewMapFromRaw(testCase.input)
+			processor.processAttributes(attributes)
+
+			assert.Equal(t, testCase.expected, attributes.AsRaw())
+		})
+	}
+}
+
+func TestSplitAttributesInvalidConfig(t *testing.T) {
+	_, err := newSplitAttributesProcessor(&SplitAttributesConfig{PairSeparator: "", KeyValueSeparator: "="})
+	assert.EqualError(t, err, "pair_separator must not be empty")
+
+	_, err = newSplitAttributesProcessor(&SplitAttributesConfig{PairSeparator: ",", KeyValueSeparator: ""})
+	assert.EqualError(t, err, "key_value_separator must not be empty")
+}
@@ -0,0 +1,48 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// AttributeProcessor transforms telemetry data in place.
+type AttributeProcessor interface {
+	ProcessLogs(plog.Logs) error
+	ProcessMetrics(pmetric.Metrics) error
+	ProcessTraces(ptrace.Traces) error
+}
+
+// SubProcessor is a single transformation step of the processor.
+// The processor may be called from multiple goroutines at the same time,
+// so implementations must not modify their own state while processing data without synchronization.
+//
+// If a SubProcessor also implements component.Component, its Start and Shutdown methods
+// are called when the processor starts and shuts down.
+type SubProcessor interface {
+	AttributeProcessor
+	IsEnabled() bool
+	ConfigPropertyName() string
+}
+
+// NewSubProcessors creates the sub-processors of the processor with the given configuration,
+// including disabled ones, in the order they are run in.
+// The sub-processors which implement component.Component have to be started before processing data.
+func NewSubProcessors(set component.ProcessorCreateSettings, config *Config) ([]SubProcessor, error) {
+	return newSubprocessors(set, config)
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestNewSubProcessors(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.DropAttributes.Enabled = true
+	config.DropAttributes.Patterns = []string{"secret"}
+
+	subprocessors, err := NewSubProcessors(newProcessorCreateSettings(), config)
+	require.NoError(t, err)
+
+	var drop SubProcessor
+	for _, subprocessor := range subprocessors {
+		if subprocessor.ConfigPropertyName() == "drop_attributes" {
+			drop = subprocessor
+		}
+	}
+	require.NotNil(t, drop)
+	assert.True(t, drop.IsEnabled())
+	assert.IsType(t, &dropAttributesProcessor{}, drop)
+
+	logs := plog.NewLogs()
+	attributes := logs.ResourceLogs().AppendEmpty().Resource().Attributes()
+	attributes.InsertString("secret", "a")
+	attributes.InsertString("public", "b")
+
+	require.NoError(t, drop.ProcessLogs(logs))
+	assert.Equal(t, map[string]interface{}{"public": "b"}, attributes.AsRaw())
+}
+
+func TestBuiltInSubProcessorsLifecycle(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.MaxCardinality = &MaxCardinalityConfig{Enabled: true, Keys: []string{"user.id"}, Limit: 10, Window: time.Minute, Action: maxCardinalityActionDrop}
+
+	subprocessors, err := NewSubProcessors(newProcessorCreateSettings(), config)
+	require.NoError(t, err)
+
+	for _, subprocessor := range subprocessors {
+		if subprocessor.ConfigPropertyName() != "max_cardinality" {
+			continue
+		}
+
+		lifecycle, ok := subprocessor.(component.Component)
+		require.True(t, ok)
+		require.NoError(t, lifecycle.Start(context.Background(), componenttest.NewNopHost()))
+		require.NoError(t, lifecycle.Shutdown(context.Background()))
+		return
+	}
+	t.Fatal("max_cardinality sub-processor not found")
+}
@@ -0,0 +1,111 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+const (
+	attributesRemovedMetricName = "sumologicschema_attributes_removed_total"
+	matchDurationMetricName     = "sumologicschema_match_duration_seconds"
+
+	signalLogs    = "logs"
+	signalMetrics = "metrics"
+	signalTraces  = "traces"
+)
+
+func init() {
+	err := view.Register(
+		viewAttributesRemoved,
+		viewMatchDuration,
+	)
+	if err != nil {
+		fmt.Printf("Failed to register sumologicschemaprocessor's views: %v\n", err)
+	}
+}
+
+var (
+	tagProcessor = tag.MustNewKey("processor")
+	tagSignal    = tag.MustNewKey("signal")
+
+	mAttributesRemoved = stats.Int64(attributesRemovedMetricName, "Number of attributes removed by a sub-processor", stats.UnitDimensionless)
+	mMatchDuration     = stats.Float64(matchDurationMetricName, "Time spent by a sub-processor on matching and modifying attributes of a batch", stats.UnitSeconds)
+)
+
+var viewAttributesRemoved = &view.View{
+	Name:        mAttributesRemoved.Name(),
+	Description: mAttributesRemoved.Description(),
+	Measure:     mAttributesRemoved,
+	TagKeys:     []tag.Key{tagProcessor, tagSignal},
+	Aggregation: view.Sum(),
+}
+
+var viewMatchDuration = &view.View{
+	Name:        mMatchDuration.Name(),
+	Description: mMatchDuration.Description(),
+	Measure:     mMatchDuration,
+	TagKeys:     []tag.Key{tagProcessor, tagSignal},
+	Aggregation: view.Distribution(0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1),
+}
+
+// processorTelemetry records metrics about the processor itself which are not recorded by the sub-processors.
+type processorTelemetry struct {
+	matchDurationEnabled bool
+}
+
+func newProcessorTelemetry(recordMatchDuration bool) *processorTelemetry {
+	return &processorTelemetry{
+		matchDurationEnabled: recordMatchDuration,
+	}
+}
+
+// recordsMatchDuration returns true if the match duration should be measured.
+func (telemetry *processorTelemetry) recordsMatchDuration() bool {
+	return telemetry.matchDurationEnabled
+}
+
+// recordMatchDuration records the time the sub-processor spent on a batch of data of the signal.
+func (telemetry *processorTelemetry) recordMatchDuration(subprocessor string, signal string, duration time.Duration) {
+	if !telemetry.matchDurationEnabled {
+		return
+	}
+
+	_ = stats.RecordWithTags(
+		context.Background(),
+		[]tag.Mutator{tag.Upsert(tagProcessor, subprocessor), tag.Upsert(tagSignal, signal)},
+		mMatchDuration.M(duration.Seconds()),
+	)
+}
+
+// recordAttributesRemoved adds the number of attributes removed by the sub-processor from data of the signal.
+// Sub-processors call it themselves, as only they know which attributes they removed.
+func recordAttributesRemoved(subprocessor string, signal string, count int) {
+	if count == 0 {
+		return
+	}
+
+	_ = stats.RecordWithTags(
+		context.Background(),
+		[]tag.Mutator{tag.Upsert(tagProcessor, subprocessor), tag.Upsert(tagSignal, signal)},
+		mAttributesRemoved.M(int64(count)),
+	)
+}
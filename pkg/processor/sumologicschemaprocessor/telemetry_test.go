@@ -0,0 +1,181 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestAttributesRemovedTelemetry(t *testing.T) {
+	resetTelemetryViews(t)
+
+	config := createDefaultConfig().(*Config)
+	config.AddCloudNamespace = false
+	config.TranslateAttributes = false
+	config.TranslateTelegrafAttributes = false
+	config.RedactAttributes.Enabled = true
+	config.RedactAttributes.Patterns = []string{"password"}
+	config.RedactAttributes.Action = redactActionRemove
+	config.DropAttributes.Enabled = true
+	config.DropAttributes.Patterns = []string{"debug.*"}
+
+	processor, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), config)
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	resourceLogs.Resource().Attributes().InsertString("debug.id", "1")
+	logAttributes := resourceLogs.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes()
+	logAttributes.InsertString("password", "secret")
+	logAttributes.InsertString("debug.line", "10")
+	logAttributes.InsertString("message", "hello")
+	_, err = processor.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+
+	metrics := pmetric.NewMetrics()
+	metric := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetDataType(pmetric.MetricDataTypeGauge)
+	metric.Gauge().DataPoints().AppendEmpty().Attributes().InsertString("debug.id", "1")
+	_, err = processor.processMetrics(context.Background(), metrics)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().Attributes().InsertString("password", "secret")
+	_, err = processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		map[string]int64{
+			"redact_attributes/logs":   1,
+			"drop_attributes/logs":     2,
+			"drop_attributes/metrics":  1,
+			"redact_attributes/traces": 1,
+		},
+		telemetryViewValues(t, viewAttributesRemoved),
+	)
+}
+
+func TestAttributesRemovedTelemetryCountsEveryRemoval(t *testing.T) {
+	resetTelemetryViews(t)
+
+	config := createDefaultConfig().(*Config)
+	config.AddCloudNamespace = false
+	config.TranslateAttributes = false
+	config.TranslateTelegrafAttributes = false
+	// Parsing JSON removes the parsed attribute, but adds more attributes than it removes.
+	config.ParseJSONAttributes = &ParseJSONAttributesConfig{Enabled: true, Attribute: "json"}
+	// A sub-processor with a condition is not run in a group of attributes sub-processors.
+	config.DropAttributes.Enabled = true
+	config.DropAttributes.Patterns = []string{"debug.*"}
+	config.Conditions = map[string]ConditionConfig{
+		"drop_attributes": {Attribute: "env", Value: "prod"},
+	}
+	config.DedupeAttributes = true
+
+	processor, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), config)
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	resourceLogs.Resource().Attributes().InsertString("host", "a")
+	logRecords := resourceLogs.ScopeLogs().AppendEmpty().LogRecords()
+	prodAttributes := logRecords.AppendEmpty().Attributes()
+	prodAttributes.InsertString("env", "prod")
+	prodAttributes.InsertString("debug.id", "1")
+	prodAttributes.InsertString("host", "a")
+	devAttributes := logRecords.AppendEmpty().Attributes()
+	devAttributes.InsertString("env", "dev")
+	devAttributes.InsertString("debug.id", "2")
+	devAttributes.InsertString("json", `{"a": 1, "b": 2}`)
+	_, err = processor.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		map[string]int64{
+			"drop_attributes/logs":       1,
+			"dedupe_attributes/logs":     1,
+			"parse_json_attributes/logs": 1,
+		},
+		telemetryViewValues(t, viewAttributesRemoved),
+	)
+}
+
+func TestMatchDurationTelemetry(t *testing.T) {
+	for _, recordMatchDuration := range []bool{true, false} {
+		resetTelemetryViews(t)
+
+		config := createDefaultConfig().(*Config)
+		config.AddCloudNamespace = false
+		config.TranslateAttributes = false
+		config.TranslateTelegrafAttributes = false
+		config.DropAttributes.Enabled = true
+		config.DropAttributes.Patterns = []string{"debug.*"}
+		config.RecordMatchDuration = recordMatchDuration
+
+		processor, err := newSumologicSchemaProcessor(newProcessorCreateSettings(), config)
+		require.NoError(t, err)
+
+		logs := plog.NewLogs()
+		logs.ResourceLogs().AppendEmpty().Resource().Attributes().InsertString("debug.id", "1")
+		for i := 0; i < 2; i++ {
+			_, err = processor.processLogs(context.Background(), logs)
+			require.NoError(t, err)
+		}
+
+		if recordMatchDuration {
+			assert.Equal(t, map[string]int64{"drop_attributes/logs": 2}, telemetryViewValues(t, viewMatchDuration))
+		} else {
+			assert.Empty(t, telemetryViewValues(t, viewMatchDuration))
+		}
+	}
+}
+
+// resetTelemetryViews registers the views of the processor again, so that previously recorded data is dropped.
+func resetTelemetryViews(t *testing.T) {
+	view.Unregister(viewAttributesRemoved, viewMatchDuration)
+	require.NoError(t, view.Register(viewAttributesRemoved, viewMatchDuration))
+}
+
+// telemetryViewValues returns the sums of counters and the counts of distributions,
+// keyed by the `processor` and `signal` tag values.
+func telemetryViewValues(t *testing.T, v *view.View) map[string]int64 {
+	rows, err := view.RetrieveData(v.Name)
+	require.NoError(t, err)
+
+	values := map[string]int64{}
+	for _, row := range rows {
+		tags := map[string]string{}
+		for _, tag := range row.Tags {
+			tags[tag.Key.Name()] = tag.Value
+		}
+		key := tags[tagProcessor.Name()] + "/" + tags[tagSignal.Name()]
+
+		switch data := row.Data.(type) {
+		case *view.SumData:
+			values[key] = int64(data.Value)
+		case *view.DistributionData:
+			values[key] = data.Count
+		}
+	}
+	return values
+}
@@ -0,0 +1,225 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	// templateMissingEmpty replaces references to missing attributes with an empty string,
+	// but doesn't set the attribute if all referenced attributes are missing.
+	templateMissingEmpty = "empty"
+	// templateMissingSkip doesn't set the attribute if any referenced attribute is missing.
+	templateMissingSkip = "skip"
+)
+
+// TemplateAttributesConfig configures the template_attributes sub-processor.
+type TemplateAttributesConfig struct {
+	Enabled    bool                `mapstructure:"enabled"`
+	Attributes []TemplateAttribute `mapstructure:"attributes"`
+	// Missing defines what happens when a referenced attribute is missing, either `empty` or `skip`.
+	Missing string `mapstructure:"missing"`
+	// Overwrite defines whether an attribute which already has the target key should be overwritten.
+	Overwrite bool `mapstructure:"overwrite"`
+}
+
+// TemplateAttribute defines an attribute built from a template.
+type TemplateAttribute struct {
+	Key string `mapstructure:"key"`
+	// Template is a string in which `{key}` is replaced with the value of the `key` attribute
+	// from the same attribute map, so a record template cannot refer to resource attributes.
+	// `{{` and `}}` stand for literal braces.
+	Template string `mapstructure:"template"`
+}
+
+// templatePart is either literal text or, if reference is set, a reference to an attribute.
+type templatePart struct {
+	literal   string
+	reference string
+}
+
+// compiledTemplate is a parsed template of a target attribute.
+type compiledTemplate struct {
+	key   string
+	parts []templatePart
+}
+
+// templateAttributesProcessor sets attributes to values built from other attributes.
+type templateAttributesProcessor struct {
+	enabled   bool
+	templates []compiledTemplate
+	skip      bool
+	overwrite bool
+}
+
+func newTemplateAttributesProcessor(config *TemplateAttributesConfig) (*templateAttributesProcessor, error) {
+	if !config.Enabled {
+		return &templateAttributesProcessor{enabled: false}, nil
+	}
+
+	if err := validateTemplateAttributesConfig(config); err != nil {
+		return nil, err
+	}
+
+	templates := make([]compiledTemplate, 0, len(config.Attributes))
+	for _, attribute := range config.Attributes {
+		parts, _ := parseTemplate(attribute.Template)
+		templates = append(templates, compiledTemplate{key: attribute.Key, parts: parts})
+	}
+
+	return &templateAttributesProcessor{
+		enabled:   true,
+		templates: templates,
+		skip:      config.Missing == templateMissingSkip,
+		overwrite: config.Overwrite,
+	}, nil
+}
+
+func validateTemplateAttributesConfig(config *TemplateAttributesConfig) error {
+	switch config.Missing {
+	case templateMissingEmpty, templateMissingSkip:
+	default:
+		return fmt.Errorf("invalid missing strategy: %q", config.Missing)
+	}
+
+	for i, attribute := range config.Attributes {
+		if attribute.Key == "" {
+			return fmt.Errorf("attribute %d: key must not be empty", i)
+		}
+		if _, err := parseTemplate(attribute.Template); err != nil {
+			return fmt.Errorf("attribute %q: %w", attribute.Key, err)
+		}
+	}
+
+	return nil
+}
+
+// parseTemplate splits a template into literal text and references to attributes.
+func parseTemplate(template string) ([]templatePart, error) {
+	parts := []templatePart{}
+	var literal strings.Builder
+
+	for i := 0; i < len(template); i++ {
+		switch {
+		case strings.HasPrefix(template[i:], "{{"), strings.HasPrefix(template[i:], "}}"):
+			literal.WriteByte(template[i])
+			i++
+		case template[i] == '}':
+			return nil, fmt.Errorf("unexpected } at offset %d, use }} for a literal brace", i)
+		case template[i] == '{':
+			end := strings.IndexAny(template[i+1:], "{}")
+			if end < 0 || template[i+1+end] == '{' {
+				return nil, fmt.Errorf("unterminated reference at offset %d", i)
+			}
+			if end == 0 {
+				return nil, errors.New("empty reference {}")
+			}
+
+			if literal.Len() > 0 {
+				parts = append(parts, templatePart{literal: literal.String()})
+				literal.Reset()
+			}
+			parts = append(parts, templatePart{reference: template[i+1 : i+1+end]})
+			i += end + 1
+		default:
+			literal.WriteByte(template[i])
+		}
+	}
+
+	if literal.Len() > 0 {
+		parts = append(parts, templatePart{literal: literal.String()})
+	}
+	return parts, nil
+}
+
+func (proc *templateAttributesProcessor) ProcessLogs(logs plog.Logs) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnLogs(proc, logs)
+	}
+	return nil
+}
+
+func (proc *templateAttributesProcessor) ProcessMetrics(metrics pmetric.Metrics) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnMetrics(proc, metrics)
+	}
+	return nil
+}
+
+func (proc *templateAttributesProcessor) ProcessTraces(traces ptrace.Traces) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnTraces(proc, traces)
+	}
+	return nil
+}
+
+func (proc *templateAttributesProcessor) IsEnabled() bool {
+	return proc.enabled
+}
+
+func (*templateAttributesProcessor) ConfigPropertyName() string {
+	return "template_attributes"
+}
+
+// processAttributes sets the attributes in the configured order,
+// so that a template can refer to an attribute set by a previous one.
+func (proc *templateAttributesProcessor) processAttributes(attributes pcommon.Map) int {
+	for _, template := range proc.templates {
+		if _, exists := attributes.Get(template.key); exists && !proc.overwrite {
+			continue
+		}
+
+		if value, ok := proc.render(template, attributes); ok {
+			attributes.UpsertString(template.key, value)
+		}
+	}
+	return 0
+}
+
+// render returns the value of the template, or false if the attribute should not be set,
+// because a referenced attribute is missing and should be skipped, or because all referenced attributes are missing.
+func (proc *templateAttributesProcessor) render(template compiledTemplate, attributes pcommon.Map) (string, bool) {
+	var value strings.Builder
+	references, resolved := 0, 0
+	for _, part := range template.parts {
+		if part.reference == "" {
+			value.WriteString(part.literal)
+			continue
+		}
+
+		references++
+		referenced, found := attributes.Get(part.reference)
+		if !found {
+			if proc.skip {
+				return "", false
+			}
+			continue
+		}
+		resolved++
+		value.WriteString(referenced.AsString())
+	}
+	if references > 0 && resolved == 0 {
+		return "", false
+	}
+	return value.String(), true
+}
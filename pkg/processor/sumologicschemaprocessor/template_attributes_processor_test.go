@@ -0,0 +1,215 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestTemplateAttributes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   TemplateAttributesConfig
+		input    map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name: "builds attribute from present references",
+			config: TemplateAttributesConfig{
+				Attributes: []TemplateAttribute{{Key: "endpoint", Template: "{http.host}:{http.port}"}},
+				Missing:    "empty",
+			},
+			input: map[string]interface{}{"http.host": "example.com", "http.port": int64(8080)},
+			expected: map[string]interface{}{
+				"http.host": "example.com",
+				"http.port": int64(8080),
+				"endpoint":  "example.com:8080",
+			},
+		},
+		{
+			name: "replaces missing references with empty string",
+			config: TemplateAttributesConfig{
+				Attributes: []TemplateAttribute{{Key: "endpoint", Template: "{http.host}:{http.port}"}},
+				Missing:    "empty",
+			},
+			input: map[string]interface{}{"http.host": "example.com"},
+			expected: map[string]interface{}{
+				"http.host": "example.com",
+				"endpoint":  "example.com:",
+			},
+		},
+		{
+			name: "does not set attribute when all references are missing",
+			config: TemplateAttributesConfig{
+				Attributes: []TemplateAttribute{{Key: "endpoint", Template: "{http.host}:{http.port}"}},
+				Missing:    "empty",
+			},
+			input:    map[string]interface{}{"other": "value"},
+			expected: map[string]interface{}{"other": "value"},
+		},
+		{
+			name: "sets attribute from template without references",
+			config: TemplateAttributesConfig{
+				Attributes: []TemplateAttribute{{Key: "source", Template: "otel"}},
+				Missing:    "empty",
+			},
+			input:    map[string]interface{}{"other": "value"},
+			expected: map[string]interface{}{"other": "value", "source": "otel"},
+		},
+		{
+			name: "skips attribute with missing references",
+			config: TemplateAttributesConfig{
+				Attributes: []TemplateAttribute{{Key: "endpoint", Template: "{http.host}:{http.port}"}},
+				Missing:    "skip",
+			},
+			input:    map[string]interface{}{"http.host": "example.com"},
+			expected: map[string]interface{}{"http.host": "example.com"},
+		},
+		{
+			name: "keeps existing attribute",
+			config: TemplateAttributesConfig{
+				Attributes: []TemplateAttribute{{Key: "endpoint", Template: "{http.host}"}},
+				Missing:    "empty",
+			},
+			input:    map[string]interface{}{"http.host": "example.com", "endpoint": "old"},
+			expected: map[string]interface{}{"http.host": "example.com", "endpoint": "old"},
+		},
+		{
+			name: "overwrites existing attribute",
+			config: TemplateAttributesConfig{
+				Attributes: []TemplateAttribute{{Key: "endpoint", Template: "{http.host}"}},
+				Missing:    "empty",
+				Overwrite:  true,
+			},
+			input:    map[string]interface{}{"http.host": "example.com", "endpoint": "old"},
+			expected: map[string]interface{}{"http.host": "example.com", "endpoint": "example.com"},
+		},
+		{
+			name: "uses attributes set by previous templates and literal braces",
+			config: TemplateAttributesConfig{
+				Attributes: []TemplateAttribute{
+					{Key: "endpoint", Template: "{http.host}:{http.port}"},
+					{Key: "label", Template: "{{{endpoint}}}"},
+				},
+				Missing: "empty",
+			},
+			input: map[string]interface{}{"http.host": "example.com", "http.port": "80"},
+			expected: map[string]interface{}{
+				"http.host": "example.com",
+				"http.port": "80",
+				"endpoint":  "example.com:80",
+				"label":     "{example.com:80}",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.config.Enabled = true
+			processor, err := newTemplateAttributesProcessor(&testCase.config)
+			require.NoError(t, err)
+
+			attributes := pcommon.NewMapFromRaw(testCase.input)
+			processor.processAttributes(attributes)
+
+			assert.Equal(t, testCase.expected, attributes.AsRaw())
+		})
+	}
+}
+
+func TestTemplateAttributesAllSignals(t *testing.T) {
+	processor, err := newTemplateAttributesProcessor(&TemplateAttributesConfig{
+		Enabled:    true,
+		Attributes: []TemplateAttribute{{Key: "endpoint", Template: "{host}:{port}"}},
+		Missing:    "skip",
+	})
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	logRecord := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	logRecord.Attributes().InsertString("host", "a")
+	logRecord.Attributes().InsertString("port", "1")
+	require.NoError(t, processor.ProcessLogs(logs))
+	assert.Equal(t, map[string]interface{}{"host": "a", "port": "1", "endpoint": "a:1"}, logRecord.Attributes().AsRaw())
+
+	metrics := pmetric.NewMetrics()
+	metric := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetDataType(pmetric.MetricDataTypeGauge)
+	dataPoint := metric.Gauge().DataPoints().AppendEmpty()
+	dataPoint.Attributes().InsertString("host", "b")
+	require.NoError(t, processor.ProcessMetrics(metrics))
+	assert.Equal(t, map[string]interface{}{"host": "b"}, dataPoint.Attributes().AsRaw())
+
+	traces := ptrace.NewTraces()
+	resourceSpans := traces.ResourceSpans().AppendEmpty()
+	resourceSpans.Resource().Attributes().InsertString("host", "c")
+	resourceSpans.Resource().Attributes().InsertInt("port", 3)
+	require.NoError(t, processor.ProcessTraces(traces))
+	assert.Equal(t, map[string]interface{}{"host": "c", "port": int64(3), "endpoint": "c:3"}, resourceSpans.Resource().Attributes().AsRaw())
+}
+
+func TestTemplateAttributesInvalidConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      TemplateAttributesConfig
+		expectedErr string
+	}{
+		{
+			name:        "invalid missing strategy",
+			config:      TemplateAttributesConfig{Missing: "fail"},
+			expectedErr: `invalid missing strategy: "fail"`,
+		},
+		{
+			name:        "empty key",
+			config:      TemplateAttributesConfig{Missing: "empty", Attributes: []TemplateAttribute{{Template: "{a}"}}},
+			expectedErr: "attribute 0: key must not be empty",
+		},
+		{
+			name:        "unterminated reference",
+			config:      TemplateAttributesConfig{Missing: "empty", Attributes: []TemplateAttribute{{Key: "a", Template: "x{b"}}},
+			expectedErr: `attribute "a": unterminated reference at offset 1`,
+		},
+		{
+			name:        "nested brace",
+			config:      TemplateAttributesConfig{Missing: "empty", Attributes: []TemplateAttribute{{Key: "a", Template: "{b{c}}"}}},
+			expectedErr: `attribute "a": unterminated reference at offset 0`,
+		},
+		{
+			name:        "empty reference",
+			config:      TemplateAttributesConfig{Missing: "empty", Attributes: []TemplateAttribute{{Key: "a", Template: "{}"}}},
+			expectedErr: `attribute "a": empty reference {}`,
+		},
+		{
+			name:        "unexpected closing brace",
+			config:      TemplateAttributesConfig{Missing: "empty", Attributes: []TemplateAttribute{{Key: "a", Template: "b}"}}},
+			expectedErr: `attribute "a": unexpected } at offset 1, use }} for a literal brace`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.config.Enabled = true
+			_, err := newTemplateAttributesProcessor(&testCase.config)
+			assert.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
@@ -15,15 +15,44 @@
 package sumologicschemaprocessor
 
 import (
+	"context"
+	"fmt"
+	"sort"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const (
+	translateDirectionOtelToSumo = "otel_to_sumo"
+	translateDirectionSumoToOtel = "sumo_to_otel"
+
+	translateScopeAll      = "all"
+	translateScopeResource = "resource"
+	translateScopeRecord   = "record"
 )
 
-// translateAttributesProcessor translates attribute names from OpenTelemetry to Sumo Logic convention
+// translateAttributesProcessor translates attribute names from OpenTelemetry to Sumo Logic convention,
+// or the other way around
 type translateAttributesProcessor struct {
+	logger          *zap.Logger
 	shouldTranslate bool
+	// extraTranslations are the user-provided translations from the configuration.
+	extraTranslations map[string]string
+	direction         string
+	mappingsFile      *mappingsFile
+	// translations holds the current map[string]string of translations.
+	// It is replaced when the mappings file is reloaded.
+	translations atomic.Value
+	// skip holds source keys which are never translated.
+	skip              map[string]struct{}
+	translateResource bool
+	translateRecord   bool
 }
 
 // attributeTranslations maps OpenTelemetry attribute names to Sumo Logic attribute names
@@ -51,38 +80,195 @@ var attributeTranslations = map[string]string{
 	"log.file.path_resolved":  "_sourceName",
 }
 
-func newTranslateAttributesProcessor(shouldTranslate bool) (*translateAttributesProcessor, error) {
-	return &translateAttributesProcessor{
-		shouldTranslate: shouldTranslate,
-	}, nil
+func newTranslateAttributesProcessor(shouldTranslate bool, extraTranslations map[string]string, direction string, skipKeys []string, scope string, mappingsFile *mappingsFile, logger *zap.Logger) (*translateAttributesProcessor, error) {
+	if err := validateTranslateDirection(direction); err != nil {
+		return nil, err
+	}
+	if err := validateTranslateScope(scope); err != nil {
+		return nil, err
+	}
+
+	skip := make(map[string]struct{}, len(skipKeys))
+	for _, key := range skipKeys {
+		skip[key] = struct{}{}
+	}
+
+	proc := &translateAttributesProcessor{
+		logger:            logger,
+		shouldTranslate:   shouldTranslate,
+		extraTranslations: extraTranslations,
+		direction:         direction,
+		mappingsFile:      mappingsFile,
+		skip:              skip,
+		translateResource: scope == translateScopeAll || scope == translateScopeResource,
+		translateRecord:   scope == translateScopeAll || scope == translateScopeRecord,
+	}
+	proc.setFileTranslations(nil)
+
+	return proc, nil
+}
+
+// setFileTranslations combines the translations from the mappings file with the built-in translations
+// and the translations from the configuration.
+func (proc *translateAttributesProcessor) setFileTranslations(fileTranslations map[string]string) {
+	translations := attributeTranslations
+	if len(fileTranslations) > 0 || len(proc.extraTranslations) > 0 {
+		translations = make(map[string]string, len(attributeTranslations)+len(fileTranslations)+len(proc.extraTranslations))
+		for otKey, sumoKey := range attributeTranslations {
+			translations[otKey] = sumoKey
+		}
+		// User-provided translations take precedence over the built-in ones,
+		// and the ones from the configuration take precedence over the ones from the file.
+		for otKey, sumoKey := range fileTranslations {
+			translations[otKey] = sumoKey
+		}
+		for otKey, sumoKey := range proc.extraTranslations {
+			translations[otKey] = sumoKey
+		}
+	}
+
+	if proc.direction == translateDirectionSumoToOtel {
+		translations = reverseTranslations(translations, proc.logger)
+	}
+
+	proc.translations.Store(translations)
+}
+
+func (proc *translateAttributesProcessor) Start(_ context.Context, _ component.Host) error {
+	if proc.mappingsFile == nil {
+		return nil
+	}
+	return proc.mappingsFile.start(proc.setFileTranslations)
+}
+
+func (proc *translateAttributesProcessor) Shutdown(_ context.Context) error {
+	if proc.mappingsFile != nil {
+		proc.mappingsFile.shutdown()
+	}
+	return nil
+}
+
+func validateTranslateDirection(direction string) error {
+	switch direction {
+	case translateDirectionOtelToSumo, translateDirectionSumoToOtel:
+		return nil
+	default:
+		return fmt.Errorf("invalid translation direction: %q", direction)
+	}
+}
+
+func validateTranslateScope(scope string) error {
+	switch scope {
+	case translateScopeAll, translateScopeResource, translateScopeRecord:
+		return nil
+	default:
+		return fmt.Errorf("invalid translation scope: %q", scope)
+	}
+}
+
+// reverseTranslations inverts the translation table, so that Sumo Logic attribute names are mapped
+// to OpenTelemetry attribute names. When several OpenTelemetry names translate to the same Sumo Logic name,
+// the first one in lexicographical order is used and a warning is logged.
+func reverseTranslations(translations map[string]string, logger *zap.Logger) map[string]string {
+	otKeys := make([]string, 0, len(translations))
+	for otKey := range translations {
+		otKeys = append(otKeys, otKey)
+	}
+	sort.Strings(otKeys)
+
+	reversed := make(map[string]string, len(translations))
+	candidates := make(map[string][]string)
+	for _, otKey := range otKeys {
+		sumoKey := translations[otKey]
+		if _, exists := reversed[sumoKey]; !exists {
+			reversed[sumoKey] = otKey
+		}
+		candidates[sumoKey] = append(candidates[sumoKey], otKey)
+	}
+
+	sumoKeys := make([]string, 0, len(candidates))
+	for sumoKey := range candidates {
+		sumoKeys = append(sumoKeys, sumoKey)
+	}
+	sort.Strings(sumoKeys)
+
+	for _, sumoKey := range sumoKeys {
+		if len(candidates[sumoKey]) > 1 {
+			logger.Warn("Attribute translation is not reversible, using the first candidate",
+				zap.String("sumo_key", sumoKey),
+				zap.Strings("otel_keys", candidates[sumoKey]),
+				zap.String("chosen", reversed[sumoKey]),
+			)
+		}
+	}
+
+	return reversed
 }
 
-func (proc *translateAttributesProcessor) processLogs(logs plog.Logs) error {
-	if proc.shouldTranslate {
-		for i := 0; i < logs.ResourceLogs().Len(); i++ {
-			translateAttributes(logs.ResourceLogs().At(i).Resource().Attributes())
+func (proc *translateAttributesProcessor) ProcessLogs(logs plog.Logs) error {
+	if !proc.shouldTranslate {
+		return nil
+	}
+
+	translations := proc.translations.Load().(map[string]string)
+
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		resourceLogs := logs.ResourceLogs().At(i)
+		if proc.translateResource {
+			translateAttributes(resourceLogs.Resource().Attributes(), translations, proc.skip)
+		}
+		if !proc.translateRecord {
+			continue
+		}
+
+		for j := 0; j < resourceLogs.ScopeLogs().Len(); j++ {
+			logRecords := resourceLogs.ScopeLogs().At(j).LogRecords()
+
+			for k := 0; k < logRecords.Len(); k++ {
+				translateAttributes(logRecords.At(k).Attributes(), translations, proc.skip)
+			}
 		}
 	}
 
 	return nil
 }
 
-func (proc *translateAttributesProcessor) processMetrics(metrics pmetric.Metrics) error {
-	if proc.shouldTranslate {
-		for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
-			translateAttributes(metrics.ResourceMetrics().At(i).Resource().Attributes())
+func (proc *translateAttributesProcessor) ProcessMetrics(metrics pmetric.Metrics) error {
+	if !proc.shouldTranslate {
+		return nil
+	}
+
+	translations := proc.translations.Load().(map[string]string)
+
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		resourceMetrics := metrics.ResourceMetrics().At(i)
+		if proc.translateResource {
+			translateAttributes(resourceMetrics.Resource().Attributes(), translations, proc.skip)
+		}
+		if !proc.translateRecord {
+			continue
+		}
+
+		for j := 0; j < resourceMetrics.ScopeMetrics().Len(); j++ {
+			metricsSlice := resourceMetrics.ScopeMetrics().At(j).Metrics()
+
+			for k := 0; k < metricsSlice.Len(); k++ {
+				processDataPointsAttributes(metricsSlice.At(k), func(attributes pcommon.Map) {
+					translateAttributes(attributes, translations, proc.skip)
+				})
+			}
 		}
 	}
 
 	return nil
 }
 
-func (proc *translateAttributesProcessor) processTraces(traces ptrace.Traces) error {
+func (proc *translateAttributesProcessor) ProcessTraces(traces ptrace.Traces) error {
 	// No-op. Traces should not be translated.
 	return nil
 }
 
-func (proc *translateAttributesProcessor) isEnabled() bool {
+func (proc *translateAttributesProcessor) IsEnabled() bool {
 	return proc.shouldTranslate
 }
 
@@ -90,12 +276,17 @@ func (*translateAttributesProcessor) ConfigPropertyName() string {
 	return "translate_attributes"
 }
 
-func translateAttributes(attributes pcommon.Map) {
+func translateAttributes(attributes pcommon.Map, translations map[string]string, skip map[string]struct{}) {
 	result := pcommon.NewMap()
 	result.EnsureCapacity(attributes.Len())
 
 	attributes.Range(func(otKey string, value pcommon.Value) bool {
-		if sumoKey, ok := attributeTranslations[otKey]; ok {
+		if _, skipped := skip[otKey]; skipped {
+			result.Insert(otKey, value)
+			return true
+		}
+
+		if sumoKey, ok := translations[otKey]; ok {
 			// Only insert if it doesn't exist yet to prevent overwriting.
 			// We have to do it this way since the final return value is not
 			// ready yet to rely on .Insert() not overwriting.
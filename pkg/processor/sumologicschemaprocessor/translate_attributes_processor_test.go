@@ -15,11 +15,20 @@
 package sumologicschemaprocessor
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestTranslateAttributes(t *testing.T) {
@@ -36,7 +45,7 @@ func TestTranslateAttributes(t *testing.T) {
 	attributes.InsertString("cloud.region", "my-region")
 	require.Equal(t, 10, attributes.Len())
 
-	translateAttributes(attributes)
+	translateAttributes(attributes, attributeTranslations, nil)
 
 	assert.Equal(t, 10, attributes.Len())
 	assertAttribute(t, attributes, "host", "testing-host")
@@ -65,7 +74,7 @@ func TestTranslateAttributesDoesNothingWhenAttributeDoesNotExist(t *testing.T) {
 	attributes := pcommon.NewMap()
 	require.Equal(t, 0, attributes.Len())
 
-	translateAttributes(attributes)
+	translateAttributes(attributes, attributeTranslations, nil)
 
 	assert.Equal(t, 0, attributes.Len())
 	assertAttribute(t, attributes, "host", "")
@@ -78,7 +87,7 @@ func TestTranslateAttributesLeavesOtherAttributesUnchanged(t *testing.T) {
 	attributes.InsertString("three", "three1")
 	require.Equal(t, 3, attributes.Len())
 
-	translateAttributes(attributes)
+	translateAttributes(attributes, attributeTranslations, nil)
 
 	assert.Equal(t, 3, attributes.Len())
 	assertAttribute(t, attributes, "one", "one1")
@@ -92,7 +101,7 @@ func TestTranslateAttributesDoesNotOverwriteExistingAttribute(t *testing.T) {
 	attributes.InsertString("host.name", "hostname1")
 	require.Equal(t, 2, attributes.Len())
 
-	translateAttributes(attributes)
+	translateAttributes(attributes, attributeTranslations, nil)
 
 	assert.Equal(t, 2, attributes.Len())
 	assertAttribute(t, attributes, "host", "host1")
@@ -109,13 +118,175 @@ func TestTranslateAttributesDoesNotOverwriteMultipleExistingAttributes(t *testin
 	attributes.InsertString("host.name", "hostname1")
 	require.Equal(t, 2, attributes.Len())
 
-	translateAttributes(attributes)
+	translateAttributes(attributes, attributeTranslations, nil)
 
 	assert.Equal(t, 2, attributes.Len())
 	assertAttribute(t, attributes, "host", "host1")
 	assertAttribute(t, attributes, "host.name", "hostname1")
 }
 
+func TestTranslateAttributesWithExtraTranslations(t *testing.T) {
+	processor, err := newTranslateAttributesProcessor(true, map[string]string{
+		"my.custom.attribute": "custom",
+		"host.name":           "hostname",
+	}, translateDirectionOtelToSumo, []string{}, translateScopeResource, nil, zap.NewNop())
+	require.NoError(t, err)
+
+	attributes := pcommon.NewMap()
+	attributes.InsertString("my.custom.attribute", "custom-value")
+	attributes.InsertString("host.name", "testing-host")
+	attributes.InsertString("k8s.pod.name", "my-pod")
+
+	translateAttributes(attributes, processor.translations.Load().(map[string]string), nil)
+
+	assert.Equal(t, 3, attributes.Len())
+	assertAttribute(t, attributes, "custom", "custom-value")
+	assertAttribute(t, attributes, "hostname", "testing-host")
+	assertAttribute(t, attributes, "host", "")
+	assertAttribute(t, attributes, "pod", "my-pod")
+
+	// The built-in translations must not be modified.
+	assert.Equal(t, "host", attributeTranslations["host.name"])
+	assert.NotContains(t, attributeTranslations, "my.custom.attribute")
+}
+
+func TestTranslateAttributesSkipsConfiguredKeys(t *testing.T) {
+	processor, err := newTranslateAttributesProcessor(true, map[string]string{}, translateDirectionOtelToSumo, []string{"host.name"}, translateScopeResource, nil, zap.NewNop())
+	require.NoError(t, err)
+
+	attributes := pcommon.NewMap()
+	attributes.InsertString("host.name", "testing-host")
+	attributes.InsertString("k8s.pod.name", "my-pod")
+
+	translateAttributes(attributes, processor.translations.Load().(map[string]string), processor.skip)
+
+	assert.Equal(t, 2, attributes.Len())
+	assertAttribute(t, attributes, "host.name", "testing-host")
+	assertAttribute(t, attributes, "host", "")
+	assertAttribute(t, attributes, "pod", "my-pod")
+	assertAttribute(t, attributes, "k8s.pod.name", "")
+}
+
+func TestTranslateAttributesScope(t *testing.T) {
+	testCases := []struct {
+		scope            string
+		expectedResource map[string]interface{}
+		expectedRecord   map[string]interface{}
+	}{
+		{
+			scope:            translateScopeAll,
+			expectedResource: map[string]interface{}{"host": "testing-host"},
+			expectedRecord:   map[string]interface{}{"pod": "my-pod"},
+		},
+		{
+			scope:            translateScopeResource,
+			expectedResource: map[string]interface{}{"host": "testing-host"},
+			expectedRecord:   map[string]interface{}{"k8s.pod.name": "my-pod"},
+		},
+		{
+			scope:            translateScopeRecord,
+			expectedResource: map[string]interface{}{"host.name": "testing-host"},
+			expectedRecord:   map[string]interface{}{"pod": "my-pod"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.scope, func(t *testing.T) {
+			processor, err := newTranslateAttributesProcessor(true, map[string]string{}, translateDirectionOtelToSumo, []string{}, testCase.scope, nil, zap.NewNop())
+			require.NoError(t, err)
+
+			logs := plog.NewLogs()
+			resourceLogs := logs.ResourceLogs().AppendEmpty()
+			resourceLogs.Resource().Attributes().InsertString("host.name", "testing-host")
+			logRecord := resourceLogs.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+			logRecord.Attributes().InsertString("k8s.pod.name", "my-pod")
+
+			require.NoError(t, processor.ProcessLogs(logs))
+			assert.Equal(t, testCase.expectedResource, resourceLogs.Resource().Attributes().AsRaw())
+			assert.Equal(t, testCase.expectedRecord, logRecord.Attributes().AsRaw())
+
+			metrics := pmetric.NewMetrics()
+			resourceMetrics := metrics.ResourceMetrics().AppendEmpty()
+			resourceMetrics.Resource().Attributes().InsertString("host.name", "testing-host")
+			metric := resourceMetrics.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+			metric.SetDataType(pmetric.MetricDataTypeGauge)
+			dataPoint := metric.Gauge().DataPoints().AppendEmpty()
+			dataPoint.Attributes().InsertString("k8s.pod.name", "my-pod")
+
+			require.NoError(t, processor.ProcessMetrics(metrics))
+			assert.Equal(t, testCase.expectedResource, resourceMetrics.Resource().Attributes().AsRaw())
+			assert.Equal(t, testCase.expectedRecord, dataPoint.Attributes().AsRaw())
+		})
+	}
+}
+
+func TestTranslateAttributesInvalidScope(t *testing.T) {
+	_, err := newTranslateAttributesProcessor(true, map[string]string{}, translateDirectionOtelToSumo, []string{}, "span", nil, zap.NewNop())
+	assert.EqualError(t, err, `invalid translation scope: "span"`)
+}
+
+func TestTranslateAttributesSumoToOtel(t *testing.T) {
+	processor, err := newTranslateAttributesProcessor(true, map[string]string{}, translateDirectionSumoToOtel, []string{}, translateScopeResource, nil, zap.NewNop())
+	require.NoError(t, err)
+
+	attributes := pcommon.NewMap()
+	attributes.InsertString("host", "testing-host")
+	attributes.InsertString("Cluster", "testing-cluster")
+	attributes.InsertString("service", "my-service")
+	attributes.InsertString("other", "other-value")
+
+	translateAttributes(attributes, processor.translations.Load().(map[string]string), nil)
+
+	assert.Equal(t, 4, attributes.Len())
+	assertAttribute(t, attributes, "host.name", "testing-host")
+	assertAttribute(t, attributes, "k8s.cluster.name", "testing-cluster")
+	// "service" has two candidates, the first one in lexicographical order is used.
+	assertAttribute(t, attributes, "k8s.service.name", "my-service")
+	assertAttribute(t, attributes, "other", "other-value")
+}
+
+func TestTranslateAttributesRoundTrip(t *testing.T) {
+	forward, err := newTranslateAttributesProcessor(true, map[string]string{}, translateDirectionOtelToSumo, []string{}, translateScopeResource, nil, zap.NewNop())
+	require.NoError(t, err)
+	reverse, err := newTranslateAttributesProcessor(true, map[string]string{}, translateDirectionSumoToOtel, []string{}, translateScopeResource, nil, zap.NewNop())
+	require.NoError(t, err)
+
+	// Only attributes which translate one-to-one survive the round trip.
+	input := map[string]interface{}{
+		"cloud.account.id":       "my-account-id",
+		"host.name":              "testing-host",
+		"k8s.cluster.name":       "testing-cluster",
+		"k8s.pod.name":           "my-pod",
+		"log.file.path_resolved": "/var/log/test.log",
+		"not.translated":         "value",
+	}
+	attributes := pcommon.NewMapFromRaw(input)
+
+	translateAttributes(attributes, forward.translations.Load().(map[string]string), nil)
+	assertAttribute(t, attributes, "host", "testing-host")
+	translateAttributes(attributes, reverse.translations.Load().(map[string]string), nil)
+
+	assert.Equal(t, input, attributes.AsRaw())
+}
+
+func TestTranslateAttributesReverseWarnsAboutAmbiguousTranslations(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	_, err := newTranslateAttributesProcessor(true, map[string]string{}, translateDirectionSumoToOtel, []string{}, translateScopeResource, nil, zap.New(core))
+	require.NoError(t, err)
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "host", entries[0].ContextMap()["sumo_key"])
+	assert.Equal(t, "host.name", entries[0].ContextMap()["chosen"])
+	assert.Equal(t, "service", entries[1].ContextMap()["sumo_key"])
+	assert.Equal(t, "k8s.service.name", entries[1].ContextMap()["chosen"])
+}
+
+func TestTranslateAttributesInvalidDirection(t *testing.T) {
+	_, err := newTranslateAttributesProcessor(true, map[string]string{}, "both", []string{}, translateScopeResource, nil, zap.NewNop())
+	assert.EqualError(t, err, `invalid translation direction: "both"`)
+}
+
 func assertAttribute(t *testing.T, metadata pcommon.Map, attributeName string, expectedValue string) {
 	value, exists := metadata.Get(attributeName)
 
@@ -151,6 +322,34 @@ var (
 
 func BenchmarkTranslateAttributes(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		translateAttributes(attributes)
+		translateAttributes(attributes, attributeTranslations, nil)
 	}
 }
+
+func TestTranslateAttributesMappingsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "translations.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"my.custom.attribute": "custom", "host.name": "hostname_from_file"}`), 0600))
+
+	processor, err := newTranslateAttributesProcessor(true, map[string]string{
+		"host.name": "hostname",
+	}, translateDirectionOtelToSumo, []string{}, translateScopeResource, newMappingsFile(path, false, zap.NewNop()), zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, processor.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, processor.Shutdown(context.Background()))
+	}()
+
+	logs := plog.NewLogs()
+	attributes := logs.ResourceLogs().AppendEmpty().Resource().Attributes()
+	attributes.InsertString("my.custom.attribute", "custom-value")
+	attributes.InsertString("host.name", "testing-host")
+	attributes.InsertString("k8s.pod.name", "my-pod")
+
+	require.NoError(t, processor.ProcessLogs(logs))
+
+	assert.Equal(t, map[string]interface{}{
+		"custom":   "custom-value",
+		"hostname": "testing-host",
+		"pod":      "my-pod",
+	}, attributes.AsRaw())
+}
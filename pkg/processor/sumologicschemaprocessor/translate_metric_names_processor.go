@@ -0,0 +1,141 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// TranslateMetricNamesConfig configures the translate_metric_names sub-processor.
+type TranslateMetricNamesConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Mapping maps metric names to new metric names.
+	Mapping map[string]string `mapstructure:"mapping"`
+	// Rules are applied in order to metrics whose names are not in Mapping. The first matching rule is used.
+	Rules []MetricNameRule `mapstructure:"rules"`
+}
+
+// MetricNameRule renames metrics whose names match a pattern.
+type MetricNameRule struct {
+	// Pattern is a wildcard pattern of metric names, see compileWildcards.
+	Pattern string `mapstructure:"pattern"`
+	// Replacement is the new metric name. Each `*` is replaced with the text matched by the corresponding `*` in Pattern.
+	Replacement string `mapstructure:"replacement"`
+}
+
+// translateMetricNamesProcessor renames metrics according to a user-provided mapping and rules.
+type translateMetricNamesProcessor struct {
+	enabled bool
+	mapping map[string]string
+	rules   []*wildcardRule
+}
+
+func newTranslateMetricNamesProcessor(config *TranslateMetricNamesConfig) (*translateMetricNamesProcessor, error) {
+	if err := validateTranslateMetricNamesConfig(config); err != nil {
+		return nil, err
+	}
+
+	rules := make([]*wildcardRule, 0, len(config.Rules))
+	for _, rule := range config.Rules {
+		// Validation has already checked that the rule compiles.
+		compiled, _ := newWildcardRule(rule.Pattern, rule.Replacement, WildcardOptions{})
+		rules = append(rules, compiled)
+	}
+
+	return &translateMetricNamesProcessor{
+		enabled: config.Enabled,
+		mapping: config.Mapping,
+		rules:   rules,
+	}, nil
+}
+
+func validateTranslateMetricNamesConfig(config *TranslateMetricNamesConfig) error {
+	for oldName, newName := range config.Mapping {
+		if newName == "" {
+			return fmt.Errorf("empty new name for metric %q", oldName)
+		}
+	}
+
+	for i, rule := range config.Rules {
+		if _, err := newWildcardRule(rule.Pattern, rule.Replacement, WildcardOptions{}); err != nil {
+			return fmt.Errorf("rule %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (proc *translateMetricNamesProcessor) ProcessLogs(_ plog.Logs) error {
+	// No-op, this subprocessor doesn't process logs.
+	return nil
+}
+
+func (proc *translateMetricNamesProcessor) ProcessMetrics(metrics pmetric.Metrics) error {
+	if !proc.enabled {
+		return nil
+	}
+
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		resourceMetrics := metrics.ResourceMetrics().At(i)
+
+		for j := 0; j < resourceMetrics.ScopeMetrics().Len(); j++ {
+			metricsSlice := resourceMetrics.ScopeMetrics().At(j).Metrics()
+
+			for k := 0; k < metricsSlice.Len(); k++ {
+				proc.translateMetricName(metricsSlice.At(k))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (proc *translateMetricNamesProcessor) ProcessTraces(_ ptrace.Traces) error {
+	// No-op, this subprocessor doesn't process traces.
+	return nil
+}
+
+func (proc *translateMetricNamesProcessor) IsEnabled() bool {
+	return proc.enabled
+}
+
+func (*translateMetricNamesProcessor) ConfigPropertyName() string {
+	return "translate_metric_names"
+}
+
+func (*translateMetricNamesProcessor) changesMetrics() {}
+
+func (proc *translateMetricNamesProcessor) translateMetricName(metric pmetric.Metric) {
+	name := metric.Name()
+	if name == "" {
+		return
+	}
+
+	if newName, ok := proc.mapping[name]; ok {
+		metric.SetName(newName)
+		return
+	}
+
+	for _, rule := range proc.rules {
+		if newName, ok := rule.replace(name); ok {
+			metric.SetName(newName)
+			return
+		}
+	}
+}
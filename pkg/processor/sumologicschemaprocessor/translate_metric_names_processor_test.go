@@ -0,0 +1,145 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestTranslateMetricNames(t *testing.T) {
+	processor, err := newTranslateMetricNamesProcessor(&TranslateMetricNamesConfig{
+		Enabled: true,
+		Mapping: map[string]string{
+			"system.cpu.usage": "cpu_usage",
+		},
+		Rules: []MetricNameRule{
+			{Pattern: `system.rate\*.*`, Replacement: "rate_*"},
+			{Pattern: "system.memory.*", Replacement: "mem_*"},
+			{Pattern: "system.*.*", Replacement: "*_*"},
+			{Pattern: "system.*", Replacement: "never_used"},
+		},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		nameIn  string
+		nameOut string
+	}{
+		{nameIn: "system.cpu.usage", nameOut: "cpu_usage"},
+		{nameIn: "system.memory.usage", nameOut: "mem_usage"},
+		{nameIn: "system.disk.io", nameOut: "disk_io"},
+		{nameIn: "system.rate*.io", nameOut: "rate_io"},
+		{nameIn: "system.rates.io", nameOut: "rates_io"},
+		{nameIn: "other.metric", nameOut: "other.metric"},
+		{nameIn: "", nameOut: ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.nameIn, func(t *testing.T) {
+			metric := pmetric.NewMetric()
+			metric.SetName(testCase.nameIn)
+			processor.translateMetricName(metric)
+			assert.Equal(t, testCase.nameOut, metric.Name())
+		})
+	}
+}
+
+func TestTranslateMetricNamesAllMetricTypes(t *testing.T) {
+	processor, err := newTranslateMetricNamesProcessor(&TranslateMetricNamesConfig{
+		Enabled: true,
+		Mapping: map[string]string{
+			"system.cpu.usage": "cpu_usage",
+		},
+	})
+	require.NoError(t, err)
+
+	dataTypes := []pmetric.MetricDataType{
+		pmetric.MetricDataTypeGauge,
+		pmetric.MetricDataTypeSum,
+		pmetric.MetricDataTypeHistogram,
+		pmetric.MetricDataTypeExponentialHistogram,
+		pmetric.MetricDataTypeSummary,
+	}
+
+	for _, dataType := range dataTypes {
+		t.Run(dataType.String(), func(t *testing.T) {
+			metrics := pmetric.NewMetrics()
+			metric := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+			metric.SetDataType(dataType)
+			metric.SetName("system.cpu.usage")
+
+			require.NoError(t, processor.ProcessMetrics(metrics))
+			assert.Equal(t, "cpu_usage", metric.Name())
+			assert.Equal(t, dataType, metric.DataType())
+		})
+	}
+}
+
+func TestTranslateMetricNamesDisabled(t *testing.T) {
+	processor, err := newTranslateMetricNamesProcessor(&TranslateMetricNamesConfig{
+		Enabled: false,
+		Mapping: map[string]string{
+			"system.cpu.usage": "cpu_usage",
+		},
+	})
+	require.NoError(t, err)
+
+	metrics := pmetric.NewMetrics()
+	metric := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("system.cpu.usage")
+
+	require.NoError(t, processor.ProcessMetrics(metrics))
+	assert.Equal(t, "system.cpu.usage", metric.Name())
+}
+
+func TestTranslateMetricNamesInvalidConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      TranslateMetricNamesConfig
+		expectedErr string
+	}{
+		{
+			name:        "empty new name",
+			config:      TranslateMetricNamesConfig{Mapping: map[string]string{"a": ""}},
+			expectedErr: `empty new name for metric "a"`,
+		},
+		{
+			name:        "empty pattern",
+			config:      TranslateMetricNamesConfig{Rules: []MetricNameRule{{Replacement: "a"}}},
+			expectedErr: "rule 0: pattern must not be empty",
+		},
+		{
+			name:        "too many wildcards in replacement",
+			config:      TranslateMetricNamesConfig{Rules: []MetricNameRule{{Pattern: "a.*", Replacement: "*_*"}}},
+			expectedErr: "rule 0: replacement has more `*` characters than pattern",
+		},
+		{
+			name:        "escaped wildcard in pattern",
+			config:      TranslateMetricNamesConfig{Rules: []MetricNameRule{{Pattern: `a\*.*`, Replacement: "*_*"}}},
+			expectedErr: "rule 0: replacement has more `*` characters than pattern",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := newTranslateMetricNamesProcessor(&testCase.config)
+			assert.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 // translateTelegrafMetricsProcessor translates metric names from OpenTelemetry to Sumo Logic convention
 type translateTelegrafMetricsProcessor struct {
 	shouldTranslate bool
+	translations    map[string]string
 }
 
 // metricsTranslations maps Telegraf metric names to corresponding names in Sumo Logic convention
@@ -81,20 +82,68 @@ var metricsTranslations = map[string]string{
 	"netstat_tcp_established": "TCP_Established",
 	"netstat_tcp_listen":      "TCP_Listen",
 	"netstat_tcp_time_wait":   "TCP_TimeWait",
+
+	// Docker metrics
+	"docker_container_cpu_usage_percent":                "cpu_percentage",
+	"docker_container_cpu_usage_total":                  "cpu_usage.total_usage",
+	"docker_container_cpu_usage_in_usermode":            "cpu_usage.usage_in_usermode",
+	"docker_container_cpu_usage_in_kernelmode":          "cpu_usage.usage_in_kernelmode",
+	"docker_container_cpu_usage_system":                 "system_cpu_usage",
+	"docker_container_cpu_throttling_periods":           "throttling_data.periods",
+	"docker_container_cpu_throttling_throttled_periods": "throttling_data.throttled_periods",
+	"docker_container_cpu_throttling_throttled_time":    "throttling_data.throttled_time",
+	"docker_container_mem_usage":                        "memory_stats.usage",
+	"docker_container_mem_max_usage":                    "memory_stats.max_usage",
+	"docker_container_mem_limit":                        "memory_stats.limit",
+	"docker_container_mem_fail_count":                   "memory_stats.failcnt",
+	"docker_container_net_rx_bytes":                     "networks.rx_bytes",
+	"docker_container_net_tx_bytes":                     "networks.tx_bytes",
+	"docker_container_net_rx_packets":                   "networks.rx_packets",
+	"docker_container_net_tx_packets":                   "networks.tx_packets",
+	"docker_container_net_rx_errors":                    "networks.rx_errors",
+	"docker_container_net_tx_errors":                    "networks.tx_errors",
+	"docker_container_net_rx_dropped":                   "networks.rx_dropped",
+	"docker_container_net_tx_dropped":                   "networks.tx_dropped",
+
+	// Kubernetes metrics
+	"kubernetes_pod_container_memory_usage_bytes":       "container_memory_usage_bytes",
+	"kubernetes_pod_container_memory_working_set_bytes": "container_memory_working_set_bytes",
+	"kubernetes_pod_container_memory_rss_bytes":         "container_memory_rss",
+	"kubernetes_pod_container_restarts_total":           "kube_pod_container_status_restarts_total",
+}
+
+// TelegrafMetricsTranslations returns a copy of the built-in table which maps
+// Telegraf metric names to corresponding names in Sumo Logic convention.
+func TelegrafMetricsTranslations() map[string]string {
+	translations := make(map[string]string, len(metricsTranslations))
+	for telegrafName, sumoName := range metricsTranslations {
+		translations[telegrafName] = sumoName
+	}
+	return translations
 }
 
-func newTranslateTelegrafMetricsProcessor(shouldTranslate bool) (*translateTelegrafMetricsProcessor, error) {
+func newTranslateTelegrafMetricsProcessor(shouldTranslate bool, extraTranslations map[string]string) (*translateTelegrafMetricsProcessor, error) {
+	translations := metricsTranslations
+	if len(extraTranslations) > 0 {
+		translations = TelegrafMetricsTranslations()
+		// User-provided translations take precedence over the built-in ones.
+		for telegrafName, sumoName := range extraTranslations {
+			translations[telegrafName] = sumoName
+		}
+	}
+
 	return &translateTelegrafMetricsProcessor{
 		shouldTranslate: shouldTranslate,
+		translations:    translations,
 	}, nil
 }
 
-func (proc *translateTelegrafMetricsProcessor) processLogs(_ plog.Logs) error {
+func (proc *translateTelegrafMetricsProcessor) ProcessLogs(_ plog.Logs) error {
 	// No-op, this subprocessor doesn't process logs.
 	return nil
 }
 
-func (proc *translateTelegrafMetricsProcessor) processMetrics(metrics pmetric.Metrics) error {
+func (proc *translateTelegrafMetricsProcessor) ProcessMetrics(metrics pmetric.Metrics) error {
 	if proc.shouldTranslate {
 		for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
 			rm := metrics.ResourceMetrics().At(i)
@@ -103,7 +152,7 @@ func (proc *translateTelegrafMetricsProcessor) processMetrics(metrics pmetric.Me
 				metricsSlice := rm.ScopeMetrics().At(j).Metrics()
 
 				for k := 0; k < metricsSlice.Len(); k++ {
-					translateTelegrafMetric(metricsSlice.At(k))
+					translateTelegrafMetric(metricsSlice.At(k), proc.translations)
 				}
 			}
 		}
@@ -112,12 +161,12 @@ func (proc *translateTelegrafMetricsProcessor) processMetrics(metrics pmetric.Me
 	return nil
 }
 
-func (proc *translateTelegrafMetricsProcessor) processTraces(_ ptrace.Traces) error {
+func (proc *translateTelegrafMetricsProcessor) ProcessTraces(_ ptrace.Traces) error {
 	// No-op, this subprocessor doesn't process traces.
 	return nil
 }
 
-func (proc *translateTelegrafMetricsProcessor) isEnabled() bool {
+func (proc *translateTelegrafMetricsProcessor) IsEnabled() bool {
 	return proc.shouldTranslate
 }
 
@@ -125,8 +174,12 @@ func (*translateTelegrafMetricsProcessor) ConfigPropertyName() string {
 	return "translate_telegraf_attributes"
 }
 
-func translateTelegrafMetric(m pmetric.Metric) {
-	name, exists := metricsTranslations[m.Name()]
+func (*translateTelegrafMetricsProcessor) changesMetrics() {}
+
+// translateTelegrafMetric renames the metric if its name is one of the translated Telegraf names.
+// Each metric is looked up only once, so a name which is already in Sumo Logic convention is left unchanged.
+func translateTelegrafMetric(m pmetric.Metric, translations map[string]string) {
+	name, exists := translations[m.Name()]
 
 	if exists {
 		m.SetName(name)
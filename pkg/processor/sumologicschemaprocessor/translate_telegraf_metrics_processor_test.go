@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
 
@@ -201,14 +202,101 @@ func TestTranslateTelegrafMetric_NamesAreTranslatedCorrectly(t *testing.T) {
 			nameIn:  "netstat_tcp_time_wait",
 			nameOut: "TCP_TimeWait",
 		},
+
+		// Docker metrics
+		{
+			nameIn:  "docker_container_cpu_usage_percent",
+			nameOut: "cpu_percentage",
+		},
+		{
+			nameIn:  "docker_container_cpu_throttling_throttled_time",
+			nameOut: "throttling_data.throttled_time",
+		},
+		{
+			nameIn:  "docker_container_mem_usage",
+			nameOut: "memory_stats.usage",
+		},
+		{
+			nameIn:  "docker_container_mem_fail_count",
+			nameOut: "memory_stats.failcnt",
+		},
+		{
+			nameIn:  "docker_container_net_rx_bytes",
+			nameOut: "networks.rx_bytes",
+		},
+
+		// Kubernetes metrics
+		{
+			nameIn:  "kubernetes_pod_container_memory_usage_bytes",
+			nameOut: "container_memory_usage_bytes",
+		},
+		{
+			nameIn:  "kubernetes_pod_container_memory_working_set_bytes",
+			nameOut: "container_memory_working_set_bytes",
+		},
+		{
+			nameIn:  "kubernetes_pod_container_memory_rss_bytes",
+			nameOut: "container_memory_rss",
+		},
+		{
+			nameIn:  "kubernetes_pod_container_restarts_total",
+			nameOut: "kube_pod_container_status_restarts_total",
+		},
+
+		// Names which are not Telegraf names are left unchanged
+		{
+			nameIn:  "system.cpu.utilization",
+			nameOut: "system.cpu.utilization",
+		},
+		{
+			nameIn:  "CPU_Total",
+			nameOut: "CPU_Total",
+		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.nameIn+"-"+tc.nameOut, func(t *testing.T) {
 			actual := pmetric.NewMetric()
 			actual.SetName(tc.nameIn)
-			translateTelegrafMetric(actual)
+			translateTelegrafMetric(actual, metricsTranslations)
 			assert.Equal(t, tc.nameOut, actual.Name())
 		})
 	}
 }
+
+func TestTranslateTelegrafMetric_TranslatedNamesAreNotTranslatedAgain(t *testing.T) {
+	for telegrafName, sumoName := range metricsTranslations {
+		assert.NotContains(t, metricsTranslations, sumoName, "translation of %s would be translated again", telegrafName)
+	}
+}
+
+func TestTranslateTelegrafMetric_ExtraTranslations(t *testing.T) {
+	processor, err := newTranslateTelegrafMetricsProcessor(true, map[string]string{
+		"custom_metric":  "Custom_Metric",
+		"cpu_usage_idle": "CPU_Idle_Custom",
+	})
+	require.NoError(t, err)
+
+	for nameIn, nameOut := range map[string]string{
+		"custom_metric":  "Custom_Metric",
+		"cpu_usage_idle": "CPU_Idle_Custom",
+		"cpu_usage_irq":  "CPU_Irq",
+	} {
+		actual := pmetric.NewMetric()
+		actual.SetName(nameIn)
+		translateTelegrafMetric(actual, processor.translations)
+		assert.Equal(t, nameOut, actual.Name())
+	}
+
+	// The built-in translations must not be modified.
+	assert.Equal(t, "CPU_Idle", metricsTranslations["cpu_usage_idle"])
+	assert.NotContains(t, metricsTranslations, "custom_metric")
+}
+
+func TestTelegrafMetricsTranslationsReturnsCopy(t *testing.T) {
+	translations := TelegrafMetricsTranslations()
+	assert.Equal(t, metricsTranslations, translations)
+
+	translations["cpu_usage_idle"] = "changed"
+	assert.Equal(t, "CPU_Idle", metricsTranslations["cpu_usage_idle"])
+}
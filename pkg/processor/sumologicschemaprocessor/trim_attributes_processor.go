@@ -0,0 +1,106 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// TrimAttributesConfig configures the trim_attributes sub-processor.
+type TrimAttributesConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Patterns are attribute keys to trim. The `*` character matches any sequence of characters.
+	Patterns []string `mapstructure:"patterns"`
+	// WildcardOptions change how Patterns are matched.
+	WildcardOptions `mapstructure:",squash"`
+	// CollapseInternal defines whether runs of whitespace inside values should be replaced with a single space.
+	CollapseInternal bool `mapstructure:"collapse_internal"`
+}
+
+// trimAttributesProcessor removes leading and trailing whitespace from string attribute values.
+type trimAttributesProcessor struct {
+	enabled          bool
+	collapseInternal bool
+	regexes          []*regexp.Regexp
+	maxKeyLength     int
+}
+
+func newTrimAttributesProcessor(config *TrimAttributesConfig) (*trimAttributesProcessor, error) {
+	regexes, err := compileWildcards(config.Patterns, config.WildcardOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &trimAttributesProcessor{
+		enabled:          config.Enabled,
+		collapseInternal: config.CollapseInternal,
+		regexes:          regexes,
+	}, nil
+}
+
+func (proc *trimAttributesProcessor) ProcessLogs(logs plog.Logs) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnLogs(proc, logs)
+	}
+	return nil
+}
+
+func (proc *trimAttributesProcessor) ProcessMetrics(metrics pmetric.Metrics) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnMetrics(proc, metrics)
+	}
+	return nil
+}
+
+func (proc *trimAttributesProcessor) ProcessTraces(traces ptrace.Traces) error {
+	if proc.enabled {
+		runAttributesSubprocessorOnTraces(proc, traces)
+	}
+	return nil
+}
+
+func (proc *trimAttributesProcessor) IsEnabled() bool {
+	return proc.enabled
+}
+
+func (*trimAttributesProcessor) ConfigPropertyName() string {
+	return "trim_attributes"
+}
+
+func (proc *trimAttributesProcessor) setMaxKeyLength(maxKeyLength int) {
+	proc.maxKeyLength = maxKeyLength
+}
+
+func (proc *trimAttributesProcessor) processAttributes(attributes pcommon.Map) int {
+	attributes.Range(func(key string, value pcommon.Value) bool {
+		if value.Type() != pcommon.ValueTypeString || !matchesAnyKeyRegex(proc.regexes, key, proc.maxKeyLength) {
+			return true
+		}
+
+		if proc.collapseInternal {
+			value.SetStringVal(strings.Join(strings.Fields(value.StringVal()), " "))
+		} else {
+			value.SetStringVal(strings.TrimSpace(value.StringVal()))
+		}
+		return true
+	})
+	return 0
+}
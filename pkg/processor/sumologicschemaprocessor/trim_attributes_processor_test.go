@@ -0,0 +1,84 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestTrimAttributes(t *testing.T) {
+	testCases := []struct {
+		name             string
+		collapseInternal bool
+		input            map[string]interface{}
+		expected         map[string]interface{}
+	}{
+		{
+			name: "trims leading and trailing whitespace",
+			input: map[string]interface{}{
+				"app.name":    "  my  app\t\n",
+				"app.version": "1.0 ",
+				"other":       " untouched ",
+			},
+			expected: map[string]interface{}{
+				"app.name":    "my  app",
+				"app.version": "1.0",
+				"other":       " untouched ",
+			},
+		},
+		{
+			name:             "collapses internal whitespace",
+			collapseInternal: true,
+			input: map[string]interface{}{
+				"app.name": "  my  \t app  ",
+			},
+			expected: map[string]interface{}{
+				"app.name": "my app",
+			},
+		},
+		{
+			name:             "leaves non-string values unchanged",
+			collapseInternal: true,
+			input: map[string]interface{}{
+				"app.port":   int64(80),
+				"app.labels": []interface{}{" a "},
+			},
+			expected: map[string]interface{}{
+				"app.port":   int64(80),
+				"app.labels": []interface{}{" a "},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			processor, err := newTrimAttributesProcessor(&TrimAttributesConfig{
+				Enabled:          true,
+				Patterns:         []string{"app.*"},
+				CollapseInternal: testCase.collapseInternal,
+			})
+			require.NoError(t, err)
+
+			attributes := pcommon.NewMapFromRaw(testCase.input)
+			processor.processAttributes(attributes)
+
+			assert.Equal(t, testCase.expected, attributes.AsRaw())
+		})
+	}
+}
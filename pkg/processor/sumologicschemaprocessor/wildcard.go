@@ -0,0 +1,147 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// WildcardOptions change how compileWildcards interprets patterns.
+// They are embedded in the configuration of every sub-processor with wildcard patterns.
+type WildcardOptions struct {
+	// Unanchored allows a pattern to match any part of the string instead of the whole string.
+	Unanchored bool `mapstructure:"unanchored"`
+	// CaseInsensitive makes letters match regardless of their case.
+	CaseInsensitive bool `mapstructure:"case_insensitive"`
+	// NoEscape makes every backslash literal, so that `*` always matches any sequence of characters.
+	NoEscape bool `mapstructure:"no_escape"`
+}
+
+// compileWildcards compiles wildcard patterns into regular expressions.
+// The `*` character matches any sequence of characters, all other characters match literally.
+// `\*` matches a literal `*` and `\\` matches a literal `\`.
+// A pattern has to match the whole string. The options change these rules.
+// Every `*` is a capturing group, so that the text it matched can be used in a replacement.
+func compileWildcards(patterns []string, opts WildcardOptions) ([]*regexp.Regexp, error) {
+	prefix, suffix := "^", "$"
+	if opts.Unanchored {
+		prefix, suffix = "", ""
+	}
+	if opts.CaseInsensitive {
+		prefix = "(?i)" + prefix
+	}
+
+	regexes := make([]*regexp.Regexp, 0, len(patterns))
+	for i, pattern := range patterns {
+		regex, err := regexp.Compile(prefix + wildcardToRegex(pattern, !opts.NoEscape) + suffix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %d %q: %w", i, pattern, err)
+		}
+		regexes = append(regexes, regex)
+	}
+	return regexes, nil
+}
+
+// matchesAnyRegex returns true if any of the regexes matches the string.
+func matchesAnyRegex(regexes []*regexp.Regexp, s string) bool {
+	for _, regex := range regexes {
+		if regex.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAnyKeyRegex returns true if any of the regexes matches the attribute key.
+// Keys longer than maxKeyLength never match, see maxKeyLengthGuard.
+func matchesAnyKeyRegex(regexes []*regexp.Regexp, key string, maxKeyLength int) bool {
+	return !exceedsMaxKeyLength(key, maxKeyLength) && matchesAnyRegex(regexes, key)
+}
+
+// wildcardToRegex converts a wildcard pattern into an unanchored regular expression.
+// If escape is true, a backslash escapes the following `*` or backslash. Any other backslash is a literal backslash.
+func wildcardToRegex(pattern string, escape bool) string {
+	var regex strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '*':
+			regex.WriteString("(.*)")
+		case '\\':
+			if escape && i+1 < len(pattern) && (pattern[i+1] == '*' || pattern[i+1] == '\\') {
+				i++
+			}
+			regex.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		default:
+			start := i
+			for i+1 < len(pattern) && pattern[i+1] != '*' && pattern[i+1] != '\\' {
+				i++
+			}
+			regex.WriteString(regexp.QuoteMeta(pattern[start : i+1]))
+		}
+	}
+	return regex.String()
+}
+
+// wildcardRule replaces the part of a string matched by a wildcard pattern with a replacement.
+// Each `*` in the replacement is replaced with the text matched by the corresponding `*` in the pattern.
+type wildcardRule struct {
+	regex *regexp.Regexp
+	// replacementParts are the parts of the replacement between the `*` characters.
+	replacementParts []string
+}
+
+func newWildcardRule(pattern string, replacement string, opts WildcardOptions) (*wildcardRule, error) {
+	if pattern == "" {
+		return nil, errors.New("pattern must not be empty")
+	}
+	if replacement == "" {
+		return nil, errors.New("replacement must not be empty")
+	}
+
+	regexes, err := compileWildcards([]string{pattern}, opts)
+	if err != nil {
+		return nil, err
+	}
+	if strings.Count(replacement, "*") > regexes[0].NumSubexp() {
+		return nil, errors.New("replacement has more `*` characters than pattern")
+	}
+
+	return &wildcardRule{
+		regex:            regexes[0],
+		replacementParts: strings.Split(replacement, "*"),
+	}, nil
+}
+
+// replace returns the string with the matched part replaced, or false if the pattern doesn't match.
+func (rule *wildcardRule) replace(s string) (string, bool) {
+	submatches := rule.regex.FindStringSubmatchIndex(s)
+	if submatches == nil {
+		return "", false
+	}
+
+	var result strings.Builder
+	result.WriteString(s[:submatches[0]])
+	for i, part := range rule.replacementParts {
+		if i > 0 {
+			result.WriteString(s[submatches[2*i]:submatches[2*i+1]])
+		}
+		result.WriteString(part)
+	}
+	result.WriteString(s[submatches[1]:])
+	return result.String(), true
+}
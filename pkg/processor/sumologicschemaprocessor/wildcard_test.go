@@ -0,0 +1,226 @@
+// Copyright 2022 Sumo Logic, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumologicschemaprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileWildcards(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		matching []string
+		other    []string
+	}{
+		{
+			pattern:  "pod_*",
+			matching: []string{"pod_", "pod_name", "pod_*"},
+			other:    []string{"my_pod_x", "pod"},
+		},
+		{
+			pattern:  `rate\*`,
+			matching: []string{"rate*"},
+			other:    []string{"rate", "rates", `rate\*`},
+		},
+		{
+			pattern:  `\**`,
+			matching: []string{"*", "*name", "**"},
+			other:    []string{"name", "n*"},
+		},
+		{
+			pattern:  `*\*.count`,
+			matching: []string{"*.count", "http_*.count"},
+			other:    []string{"http_.count", "http_*count"},
+		},
+		{
+			pattern:  `path\\*`,
+			matching: []string{`path\`, `path\x`},
+			other:    []string{"path", "pathx"},
+		},
+		{
+			pattern:  `a\b.c`,
+			matching: []string{`a\b.c`},
+			other:    []string{"abxc", `a\bxc`},
+		},
+		{
+			pattern:  `trailing\`,
+			matching: []string{`trailing\`},
+			other:    []string{"trailing"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.pattern, func(t *testing.T) {
+			regexes, err := compileWildcards([]string{testCase.pattern}, WildcardOptions{})
+			require.NoError(t, err)
+
+			for _, s := range testCase.matching {
+				assert.True(t, matchesAnyRegex(regexes, s), "%q should match", s)
+			}
+			for _, s := range testCase.other {
+				assert.False(t, matchesAnyRegex(regexes, s), "%q should not match", s)
+			}
+		})
+	}
+}
+
+func TestCompileWildcardsOptions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pattern  string
+		opts     WildcardOptions
+		matching []string
+		other    []string
+	}{
+		{
+			name:     "defaults",
+			pattern:  `Pod\*`,
+			opts:     WildcardOptions{},
+			matching: []string{"Pod*"},
+			other:    []string{"pod*", "my_Pod*", `Pod\x`},
+		},
+		{
+			name:     "unanchored",
+			pattern:  `Pod\*`,
+			opts:     WildcardOptions{Unanchored: true},
+			matching: []string{"Pod*", "my_Pod*_name"},
+			other:    []string{"my_pod*_name", `Pod\x`},
+		},
+		{
+			name:     "case insensitive",
+			pattern:  `Pod\*`,
+			opts:     WildcardOptions{CaseInsensitive: true},
+			matching: []string{"Pod*", "pod*", "POD*"},
+			other:    []string{"my_pod*", `pod\x`},
+		},
+		{
+			name:     "no escape",
+			pattern:  `Pod\*`,
+			opts:     WildcardOptions{NoEscape: true},
+			matching: []string{`Pod\`, `Pod\x`},
+			other:    []string{"Pod*", `pod\x`, `my_Pod\x`},
+		},
+		{
+			name:     "unanchored and case insensitive",
+			pattern:  `Pod\*`,
+			opts:     WildcardOptions{Unanchored: true, CaseInsensitive: true},
+			matching: []string{"Pod*", "my_pod*_name", "POD*"},
+			other:    []string{"my_pod_name", `pod\x`},
+		},
+		{
+			name:     "unanchored and no escape",
+			pattern:  `Pod\*`,
+			opts:     WildcardOptions{Unanchored: true, NoEscape: true},
+			matching: []string{`Pod\`, `my_Pod\x`},
+			other:    []string{"Pod*", `my_pod\x`},
+		},
+		{
+			name:     "case insensitive and no escape",
+			pattern:  `Pod\*`,
+			opts:     WildcardOptions{CaseInsensitive: true, NoEscape: true},
+			matching: []string{`Pod\`, `pod\x`, `POD\x`},
+			other:    []string{"pod*", `my_pod\x`},
+		},
+		{
+			name:     "all options",
+			pattern:  `Pod\*`,
+			opts:     WildcardOptions{Unanchored: true, CaseInsensitive: true, NoEscape: true},
+			matching: []string{`Pod\`, `my_pod\x`, `xPOD\`},
+			other:    []string{"pod*", "my_pod_name"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			regexes, err := compileWildcards([]string{testCase.pattern}, testCase.opts)
+			require.NoError(t, err)
+
+			for _, s := range testCase.matching {
+				assert.True(t, matchesAnyRegex(regexes, s), "%q should match", s)
+			}
+			for _, s := range testCase.other {
+				assert.False(t, matchesAnyRegex(regexes, s), "%q should not match", s)
+			}
+		})
+	}
+}
+
+func TestWildcardRule(t *testing.T) {
+	testCases := []struct {
+		name        string
+		pattern     string
+		replacement string
+		opts        WildcardOptions
+		input       string
+		expected    string
+		matches     bool
+	}{
+		{
+			name:        "replaces wildcards",
+			pattern:     "system.*.*",
+			replacement: "*_*",
+			input:       "system.disk.io",
+			expected:    "disk_io",
+			matches:     true,
+		},
+		{
+			name:        "escaped wildcard is not replaced",
+			pattern:     `rate\*.*`,
+			replacement: "rate_*",
+			input:       "rate*.count",
+			expected:    "rate_count",
+			matches:     true,
+		},
+		{
+			name:        "unmatched string",
+			pattern:     "system.*",
+			replacement: "host_*",
+			input:       "other.metric",
+			matches:     false,
+		},
+		{
+			name:        "unanchored replaces only the matched part",
+			pattern:     "pod_",
+			replacement: "k8s.pod.",
+			opts:        WildcardOptions{Unanchored: true},
+			input:       "my_pod_name",
+			expected:    "my_k8s.pod.name",
+			matches:     true,
+		},
+		{
+			name:        "case insensitive",
+			pattern:     "pod_*",
+			replacement: "k8s.pod.*",
+			opts:        WildcardOptions{CaseInsensitive: true},
+			input:       "POD_Name",
+			expected:    "k8s.pod.Name",
+			matches:     true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rule, err := newWildcardRule(testCase.pattern, testCase.replacement, testCase.opts)
+			require.NoError(t, err)
+
+			result, ok := rule.replace(testCase.input)
+			assert.Equal(t, testCase.matches, ok)
+			assert.Equal(t, testCase.expected, result)
+		})
+	}
+}